@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"polynode/i18n"
+	"polynode/pkg/manager"
+	"polynode/shared"
+)
+
+// aliasListOutput es la salida estructurada de "poly alias list"
+type aliasListOutput struct {
+	Aliases []aliasOutput `json:"aliases"`
+}
+
+type aliasOutput struct {
+	Name      string `json:"name"`
+	Target    string `json:"target"`
+	Version   string `json:"version"`
+	Installed bool   `json:"installed"`
+}
+
+func ExecuteAlias(args []string) error {
+	if len(args) < 1 {
+		return usageError("cli.command_usage", placeholders("alias <set|list|rm|refresh> [nombre] [versión]"))
+	}
+
+	switch args[0] {
+	case "set":
+		if len(args) != 3 {
+			return usageError("cli.command_usage", placeholders("alias set <nombre> <versión>"))
+		}
+		if err := manager.ValidateAliasName(args[1]); err != nil {
+			return withCode(ErrorCodeUsage, err)
+		}
+		alias, err := newManager(newConsoleReporter(false)).SetAlias(args[1], args[2])
+		if err != nil {
+			return err
+		}
+		printInfo("alias.set", alias.Name, alias.Version)
+		return nil
+
+	case "list":
+		return listAliases()
+
+	case "rm":
+		if len(args) != 2 {
+			return usageError("cli.command_usage", placeholders("alias rm <nombre>"))
+		}
+		if err := manager.RemoveAlias(args[1]); err != nil {
+			return err
+		}
+		printInfo("alias.removed", args[1])
+		return nil
+
+	case "refresh":
+		if len(args) != 1 {
+			return usageError("cli.command_usage", "alias refresh")
+		}
+		changes, err := newManager(newConsoleReporter(false)).RefreshAliases()
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			printInfo("alias.up_to_date")
+		}
+		for _, change := range changes {
+			printInfo("alias.refreshed", change.Name, change.Target, change.Previous, change.Version)
+		}
+		return nil
+	}
+
+	return usageError("cli.unknown_subcommand", "alias", args[0], "")
+}
+
+func listAliases() error {
+	aliases, err := manager.Aliases()
+	if err != nil {
+		return err
+	}
+
+	if StructuredOutput() {
+		output := aliasListOutput{Aliases: []aliasOutput{}}
+		for _, alias := range aliases {
+			output.Aliases = append(output.Aliases, aliasOutput{
+				Name:      alias.Name,
+				Target:    alias.Target,
+				Version:   alias.Version,
+				Installed: isInstalled(alias.Version),
+			})
+		}
+		return writeOutput(output)
+	}
+
+	if len(aliases) == 0 {
+		fmt.Println(i18n.T("alias.empty"))
+		fmt.Println(i18n.T("alias.empty_hint"))
+		return nil
+	}
+
+	fmt.Println(i18n.T("alias.title"))
+	for _, alias := range aliases {
+		line := fmt.Sprintf(" - %-12s -> %s", alias.Name, alias.Target)
+		if alias.Channel() {
+			line += fmt.Sprintf(" (%s)", alias.Version)
+		}
+		if !isInstalled(alias.Version) {
+			line += i18n.T("alias.not_installed_marker")
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
+
+// aliasesByVersion devuelve los nombres de los alias que apuntan a cada versión
+func aliasesByVersion() map[string][]string {
+	byVersion := map[string][]string{}
+	aliases, _ := manager.Aliases()
+	for _, alias := range aliases {
+		byVersion[alias.Version] = append(byVersion[alias.Version], alias.Name)
+	}
+	return byVersion
+}
+
+// isInstalled indica si una versión está instalada en el repositorio local
+func isInstalled(version string) bool {
+	_, err := os.Stat(shared.GetVersionPath(version))
+	return err == nil
+}
+
+// completeAliases devuelve los nombres de los alias definidos
+func completeAliases() []string {
+	aliases, _ := manager.Aliases()
+	var names []string
+	for _, alias := range aliases {
+		names = append(names, alias.Name)
+	}
+	return names
+}
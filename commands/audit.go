@@ -0,0 +1,127 @@
+package commands
+
+import (
+	"fmt"
+	"polynode/i18n"
+	"polynode/pkg/manager"
+	"polynode/shared"
+	"strings"
+)
+
+// auditOutput es la salida estructurada de "poly audit"
+type auditOutput struct {
+	Versions []auditVersionOutput `json:"versions"`
+}
+
+type auditVersionOutput struct {
+	Version          string                  `json:"version"`
+	Vulnerable       bool                    `json:"vulnerable"`
+	SecurityReleases []securityReleaseOutput `json:"securityReleases"`
+	Latest           string                  `json:"latest"`
+	Current          bool                    `json:"current"`
+}
+
+type securityReleaseOutput struct {
+	Version string `json:"version"`
+	Date    string `json:"date"`
+}
+
+/*
+ExecuteAudit busca las versiones instaladas que fueron reemplazadas por una versión de seguridad
+de su misma línea (campo security de index.json) e indica a qué versión actualizarlas. Si alguna
+versión tiene vulnerabilidades conocidas, termina con el código de salida 7.
+*/
+func ExecuteAudit(args []string) error {
+	if len(args) > 0 {
+		return usageError("cli.command_usage", "audit")
+	}
+
+	versions, err := listInstalledVersions()
+	if err != nil {
+		return err
+	}
+
+	m := newManager(nil)
+	if !StructuredOutput() {
+		m = newManager(newConsoleReporter(false))
+	}
+	index, err := m.Index()
+	if err != nil {
+		return err
+	}
+	latest := latestByLine(index)
+	currentVersion, _ := m.Current()
+
+	output := auditOutput{Versions: []auditVersionOutput{}}
+	vulnerable := 0
+	for _, version := range versions {
+		parsed, err := manager.ParseVersion(version)
+		if err != nil {
+			continue
+		}
+		item := auditVersionOutput{
+			Version:          version,
+			SecurityReleases: []securityReleaseOutput{},
+			Latest:           version,
+			Current:          version == currentVersion,
+		}
+		if newest, ok := latest[manager.ReleaseLineName(parsed)]; ok && manager.CompareVersions(newest, parsed) > 0 {
+			item.Latest = fmt.Sprintf("%d.%d.%d", newest.Major, newest.Minor, newest.Patch)
+		}
+		for _, fix := range manager.SecurityFixes(index, version) {
+			item.SecurityReleases = append(item.SecurityReleases, securityReleaseOutput{
+				Version: shared.NormalizeVersion(fix.Version),
+				Date:    fix.Date,
+			})
+		}
+		if len(item.SecurityReleases) > 0 {
+			item.Vulnerable = true
+			vulnerable++
+		}
+		output.Versions = append(output.Versions, item)
+	}
+
+	var auditErr error
+	if vulnerable > 0 {
+		auditErr = withCode(ErrorCodeVulnerable, i18n.Nerrorf("audit.vulnerable_found", vulnerable, vulnerable))
+	}
+
+	if StructuredOutput() {
+		if err := writeOutput(output); err != nil {
+			return err
+		}
+		if auditErr != nil {
+			return &reportedError{auditErr}
+		}
+		return nil
+	}
+
+	if len(output.Versions) == 0 {
+		fmt.Println(i18n.T("list.empty"))
+		fmt.Println(i18n.T("list.empty_hint"))
+		return nil
+	}
+
+	fmt.Println(i18n.T("audit.title"))
+	for _, item := range output.Versions {
+		version := item.Version
+		if item.Current {
+			version = "[" + version + "]"
+		}
+		if !item.Vulnerable {
+			fmt.Print(i18n.T("audit.ok", version))
+			continue
+		}
+		var releases []string
+		for _, release := range item.SecurityReleases {
+			releases = append(releases, fmt.Sprintf("%s (%s)", release.Version, release.Date))
+		}
+		fmt.Print(i18n.N("audit.vulnerable", len(releases), version, strings.Join(releases, ", "), item.Latest))
+	}
+
+	if auditErr == nil {
+		fmt.Println()
+		fmt.Println(i18n.T("audit.no_vulnerabilities"))
+	}
+	return auditErr
+}
@@ -1,81 +1,360 @@
 package commands
 
 import (
-	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
+	"polynode/i18n"
 	"polynode/shared"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
-func BackupInstallation() error {
-	installPath := shared.GetInstallPath()
+const (
+	backupSuffix   = "-polynode"
+	backupRootName = "polynode"
+)
 
-	// Generar el nombre del directorio de backup con un timestamp
-	timestamp := time.Now().Format("20060102T150405")
-	backupFilename := fmt.Sprintf("%s-polynode", timestamp)
-	zipFileName := filepath.Join(installPath, fmt.Sprintf("%s.zip", backupFilename))
+// backupDirs son los directorios del espacio de trabajo incluidos en las copias de seguridad
+var backupDirs = []string{"current", "repository"}
 
-	// Crear el archivo zip
-	zipFile, err := os.Create(zipFileName)
-	if err != nil {
-		return fmt.Errorf("error al crear el archivo zip: %w", err)
+// backupOptions son las opciones de "poly backup"
+type backupOptions struct {
+	Output string
+	Format string
+	Level  int
+	Keep   int
+}
+
+func ExecuteBackup(args []string) error {
+	options := backupOptions{Format: shared.GetConfig("backup_format")}
+	formatSet := false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--output", "--format", "--level", "--keep":
+			if i+1 >= len(args) {
+				return usageError("cli.missing_flag_value", args[i])
+			}
+			value := args[i+1]
+			i++
+
+			switch args[i-1] {
+			case "--output":
+				options.Output = value
+			case "--format":
+				options.Format = value
+				formatSet = true
+			default:
+				number, err := strconv.Atoi(value)
+				if err != nil || number < 0 {
+					return usageError("cli.invalid_flag_value", args[i-1], value)
+				}
+				if args[i-1] == "--level" {
+					options.Level = number
+				} else {
+					options.Keep = number
+				}
+			}
+		default:
+			return usageError("cli.command_usage", placeholders("backup [--output <directorio|archivo>] [--format zip|tar.gz|tar.zst] [--level <n>] [--keep <n>]"))
+		}
 	}
-	defer zipFile.Close()
 
-	// Crear un escritor de zip
-	zipWriter := zip.NewWriter(zipFile)
-	defer zipWriter.Close()
+	// Si --output es un archivo, el formato se deduce de su extensión
+	if format := backupFormatForPath(options.Output); format != "" && !formatSet {
+		options.Format = format
+	}
+
+	fileName, err := createBackup(options)
+	if err != nil {
+		return err
+	}
+	printInfo("backup.created", fileName)
 
-	// Función para recorrer los directorios y agregar archivos al zip
-	addFileToZip := func(filePath string, fileInfo os.FileInfo, err error) error {
+	if options.Keep > 0 {
+		removed, err := pruneBackups(filepath.Dir(fileName), options.Keep)
 		if err != nil {
 			return err
 		}
-		if !fileInfo.Mode().IsRegular() {
-			return nil // Ignorar directorios y otros tipos de archivos
+		for _, backup := range removed {
+			printInfo("backup.pruned", backup.Path)
 		}
+	}
+	return nil
+}
+
+func BackupInstallation() error {
+	return ExecuteBackup(nil)
+}
+
+// getBackupDir devuelve el directorio donde se guardan las copias de seguridad
+func getBackupDir() string {
+	if dir := shared.GetConfig("backup_dir"); dir != "" {
+		return dir
+	}
+	return shared.GetInstallPath()
+}
+
+/*
+createBackup genera el archivo de copia de seguridad y devuelve su ruta. Se guardan los
+directorios current y repository con sus permisos, enlaces simbólicos y directorios vacíos,
+junto con un manifiesto con el SHA-256 de cada archivo, que se verifica al restaurar.
+*/
+func createBackup(options backupOptions) (string, error) {
+	installPath := shared.GetInstallPath()
 
-		// Abrir el archivo
-		file, err := os.Open(filePath)
+	extension, ok := backupFormats[options.Format]
+	if !ok {
+		return "", i18n.Errorf("backup.unsupported_format", options.Format)
+	}
+	if err := validateBackupLevel(options.Format, options.Level); err != nil {
+		return "", err
+	}
+
+	// Generar el nombre del archivo de backup con un timestamp
+	timestamp := time.Now().Format("20060102T150405")
+	fileName := filepath.Join(getBackupDir(), timestamp+backupSuffix+extension)
+	explicitFile := backupFormatForPath(options.Output) != ""
+	if options.Output != "" {
+		if explicitFile {
+			fileName = options.Output
+		} else {
+			fileName = filepath.Join(options.Output, timestamp+backupSuffix+extension)
+		}
+	}
+
+	// La copia no puede quedar dentro de los directorios que se están copiando
+	absFileName, _ := filepath.Abs(fileName)
+	for _, dir := range backupDirs {
+		absDir, _ := filepath.Abs(filepath.Join(installPath, dir))
+		if strings.HasPrefix(absFileName, absDir+string(os.PathSeparator)) {
+			return "", i18n.Errorf("backup.inside_workspace", absDir)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+		return "", i18n.Errorf("backup.destination_error", err)
+	}
+
+	// Crear el archivo, sin sobrescribir una copia de seguridad existente. Si ya hay una copia
+	// con el mismo timestamp (por ejemplo, la copia previa a una restauración), se agrega un número
+	outFile, err := os.OpenFile(fileName, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	for n := 2; os.IsExist(err) && !explicitFile && n < 100; n++ {
+		fileName = filepath.Join(filepath.Dir(fileName), fmt.Sprintf("%s-%d%s%s", timestamp, n, backupSuffix, extension))
+		outFile, err = os.OpenFile(fileName, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	}
+	if err != nil {
+		return "", i18n.Errorf("backup.create_error", err)
+	}
+	defer outFile.Close()
+
+	writer, err := newBackupWriter(outFile, options.Format, options.Level)
+	if err != nil {
+		outFile.Close()
+		os.Remove(fileName)
+		return "", err
+	}
+
+	manifest := backupManifest{Created: time.Now().UTC(), Format: options.Format}
+
+	// Función para recorrer los directorios y agregar archivos, directorios y enlaces
+	addToBackup := func(filePath string, fileInfo os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
-		defer file.Close()
 
-		// Obtener el nombre relativo del archivo
+		// Obtener el nombre relativo del archivo; dentro de la copia se usa siempre "/"
 		relativePath, err := filepath.Rel(installPath, filePath)
 		if err != nil {
 			return err
 		}
+		entry := backupEntry{
+			Name:    path.Join(backupRootName, filepath.ToSlash(relativePath)),
+			Mode:    fileInfo.Mode(),
+			ModTime: fileInfo.ModTime(),
+			Size:    fileInfo.Size(),
+		}
+		manifestEntry := backupManifestEntry{Path: entry.Name, Mode: fmt.Sprintf("%04o", fileInfo.Mode().Perm())}
 
-		// Crear un nuevo archivo en el zip
-		zipEntry, err := zipWriter.Create(filepath.Join("polynode", relativePath))
-		if err != nil {
-			return err
+		switch {
+		case fileInfo.IsDir():
+			manifestEntry.Type = "dir"
+			if err := writer.Add(entry, nil); err != nil {
+				return err
+			}
+
+		case fileInfo.Mode()&os.ModeSymlink != 0:
+			if entry.Linkname, err = os.Readlink(filePath); err != nil {
+				return err
+			}
+			manifestEntry.Type = "symlink"
+			manifestEntry.Link = entry.Linkname
+			if err := writer.Add(entry, nil); err != nil {
+				return err
+			}
+
+		case fileInfo.Mode().IsRegular():
+			file, err := os.Open(filePath)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			hash := sha256.New()
+			if err := writer.Add(entry, io.TeeReader(file, hash)); err != nil {
+				return err
+			}
+			manifestEntry.Type = "file"
+			manifestEntry.Size = fileInfo.Size()
+			manifestEntry.SHA256 = hex.EncodeToString(hash.Sum(nil))
+
+		default:
+			return nil // Ignorar otros tipos de archivos
+		}
+
+		manifest.Files = append(manifest.Files, manifestEntry)
+		return nil
+	}
+
+	// Recorrer los directorios "current" y "repository" y agregar su contenido
+	for _, dir := range backupDirs {
+		root := filepath.Join(installPath, dir)
+		if _, err := os.Lstat(root); os.IsNotExist(err) {
+			continue
+		}
+		if err := filepath.Walk(root, addToBackup); err != nil {
+			writer.Close()
+			os.Remove(fileName)
+			return "", i18n.Errorf("backup.add_error", dir, err)
+		}
+	}
+
+	// El manifiesto va al final, cuando ya se conocen los hashes de todos los archivos
+	manifestJSON, err := json.MarshalIndent(manifest, "", "    ")
+	if err == nil {
+		err = writer.Add(backupEntry{
+			Name:    path.Join(backupRootName, backupManifestName),
+			Mode:    0644,
+			ModTime: manifest.Created,
+			Size:    int64(len(manifestJSON)),
+		}, strings.NewReader(string(manifestJSON)))
+	}
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(fileName)
+		return "", i18n.Errorf("backup.write_error", err)
+	}
+
+	return fileName, nil
+}
+
+// backupFile es una copia de seguridad encontrada en el directorio de copias
+type backupFile struct {
+	Path    string
+	Size    int64
+	Created time.Time
+}
+
+// findBackups devuelve las copias de seguridad de un directorio, de la más reciente a la más antigua
+func findBackups(dir string) ([]backupFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
 		}
+		return nil, i18n.Errorf("backup.read_dir_error", err)
+	}
 
-		// Copiar el contenido del archivo al zip
-		_, err = io.Copy(zipEntry, file)
+	var backups []backupFile
+	for _, entry := range entries {
+		format := backupFormatForPath(entry.Name())
+		baseName := strings.TrimSuffix(entry.Name(), backupFormats[format])
+		if entry.IsDir() || format == "" || !strings.HasSuffix(baseName, backupSuffix) {
+			continue
+		}
+		info, err := entry.Info()
 		if err != nil {
-			return err
+			continue
 		}
+		timestamp, _, _ := strings.Cut(strings.TrimSuffix(baseName, backupSuffix), "-")
+		created, err := time.ParseInLocation("20060102T150405", timestamp, time.Local)
+		if err != nil {
+			created = info.ModTime()
+		}
+		backups = append(backups, backupFile{
+			Path:    filepath.Join(dir, entry.Name()),
+			Size:    info.Size(),
+			Created: created,
+		})
+	}
 
-		return nil
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Created.After(backups[j].Created)
+	})
+	return backups, nil
+}
+
+// pruneBackups elimina las copias de seguridad de un directorio, salvo las keep más recientes
+func pruneBackups(dir string, keep int) ([]backupFile, error) {
+	backups, err := findBackups(dir)
+	if err != nil || len(backups) <= keep {
+		return nil, err
 	}
 
-	// Recorrer el directorio "current" y agregar archivos al zip
-	if err := filepath.Walk(filepath.Join(installPath, "current"), addFileToZip); err != nil {
-		return fmt.Errorf("error al agregar archivos de 'current' al zip: %w", err)
+	var removed []backupFile
+	for _, backup := range backups[keep:] {
+		if err := os.Remove(backup.Path); err != nil {
+			return removed, i18n.Errorf("backup.remove_error", backup.Path, err)
+		}
+		removed = append(removed, backup)
 	}
+	return removed, nil
+}
 
-	// Recorrer el directorio "repository" y agregar archivos al zip
-	if err := filepath.Walk(filepath.Join(installPath, "repository"), addFileToZip); err != nil {
-		return fmt.Errorf("error al agregar archivos de 'repository' al zip: %w", err)
+// backupListOutput es la salida estructurada de "poly backup list"; los tamaños están en bytes
+type backupListOutput struct {
+	Directory string         `json:"directory"`
+	Backups   []backupOutput `json:"backups"`
+}
+
+type backupOutput struct {
+	Path    string    `json:"path"`
+	Size    int64     `json:"size"`
+	Created time.Time `json:"created"`
+}
+
+func listBackups() error {
+	backups, err := findBackups(getBackupDir())
+	if err != nil {
+		return err
 	}
 
-	fmt.Printf("Se creó una copia de seguridad en: %s\n", zipFileName)
+	if StructuredOutput() {
+		output := backupListOutput{Directory: getBackupDir(), Backups: []backupOutput{}}
+		for _, backup := range backups {
+			output.Backups = append(output.Backups, backupOutput{Path: backup.Path, Size: backup.Size, Created: backup.Created})
+		}
+		return writeOutput(output)
+	}
+
+	if len(backups) == 0 {
+		fmt.Print(i18n.T("backup.list_empty", getBackupDir()))
+		fmt.Println(i18n.T("backup.list_empty_hint"))
+		return nil
+	}
+
+	fmt.Println(i18n.T("backup.list_title"))
+	for _, backup := range backups {
+		fmt.Printf(" - %s  %10s  %s\n", backup.Created.Format("2006-01-02 15:04:05"), shared.FormatSize(backup.Size), backup.Path)
+	}
 	return nil
 }
@@ -0,0 +1,327 @@
+package commands
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/flate"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"polynode/i18n"
+	"strings"
+	"time"
+)
+
+const backupManifestName = "manifest.json"
+
+// backupFormats son los formatos de copia de seguridad soportados, con la extensión de cada uno
+var backupFormats = map[string]string{
+	"zip":     ".zip",
+	"tar.gz":  ".tar.gz",
+	"tar.zst": ".tar.zst",
+}
+
+// backupManifest se guarda dentro de la copia de seguridad y describe cada archivo incluido
+type backupManifest struct {
+	Created time.Time             `json:"created"`
+	Format  string                `json:"format"`
+	Files   []backupManifestEntry `json:"files"`
+}
+
+type backupManifestEntry struct {
+	Path   string `json:"path"`
+	Type   string `json:"type"`
+	Mode   string `json:"mode"`
+	Size   int64  `json:"size,omitempty"`
+	SHA256 string `json:"sha256,omitempty"`
+	Link   string `json:"link,omitempty"`
+}
+
+// backupEntry es un archivo, directorio o enlace simbólico de una copia de seguridad
+type backupEntry struct {
+	Name     string
+	Mode     os.FileMode
+	Linkname string
+	ModTime  time.Time
+	Size     int64
+}
+
+// backupWriter agrega entradas a una copia de seguridad en alguno de los formatos soportados
+type backupWriter interface {
+	Add(entry backupEntry, r io.Reader) error
+	Close() error
+}
+
+// backupFormatForPath deduce el formato de una copia de seguridad a partir de su extensión
+func backupFormatForPath(path string) string {
+	name := strings.ToLower(path)
+	for format, extension := range backupFormats {
+		if strings.HasSuffix(name, extension) {
+			return format
+		}
+	}
+	return ""
+}
+
+/*
+newBackupWriter crea el escritor para el formato indicado. El nivel de compresión va de 1 a 9
+(de 1 a 19 para tar.zst); 0 usa el nivel por defecto del formato. Go no incluye un compresor
+zstd, por lo que tar.zst se genera con el comando zstd del sistema.
+*/
+func newBackupWriter(out *os.File, format string, level int) (backupWriter, error) {
+	switch format {
+	case "zip":
+		zw := zip.NewWriter(out)
+		if level > 0 {
+			zw.RegisterCompressor(zip.Deflate, func(w io.Writer) (io.WriteCloser, error) {
+				return flate.NewWriter(w, level)
+			})
+		}
+		return &zipBackupWriter{zw: zw}, nil
+
+	case "tar.gz":
+		if level == 0 {
+			level = gzip.DefaultCompression
+		}
+		gz, err := gzip.NewWriterLevel(out, level)
+		if err != nil {
+			return nil, err
+		}
+		return &tarBackupWriter{tw: tar.NewWriter(gz), closers: []io.Closer{gz}}, nil
+
+	case "tar.zst":
+		if level == 0 {
+			level = 3
+		}
+		cmd := exec.Command("zstd", "-q", fmt.Sprintf("-%d", level), "-c")
+		cmd.Stdout = out
+		stdin, err := cmd.StdinPipe()
+		if err != nil {
+			return nil, err
+		}
+		if err := cmd.Start(); err != nil {
+			return nil, i18n.Errorf("backup.zstd_error", err)
+		}
+		return &tarBackupWriter{tw: tar.NewWriter(stdin), closers: []io.Closer{stdin, commandCloser{cmd}}}, nil
+	}
+	return nil, i18n.Errorf("backup.unsupported_format", format)
+}
+
+// validateBackupLevel verifica que el nivel de compresión sea válido para el formato
+func validateBackupLevel(format string, level int) error {
+	max := 9
+	if format == "tar.zst" {
+		max = 19
+	}
+	if level < 0 || level > max {
+		return i18n.Errorf("backup.invalid_level", format, max)
+	}
+	return nil
+}
+
+type zipBackupWriter struct {
+	zw *zip.Writer
+}
+
+func (w *zipBackupWriter) Add(entry backupEntry, r io.Reader) error {
+	header := &zip.FileHeader{Name: entry.Name, Method: zip.Deflate, Modified: entry.ModTime}
+	header.SetMode(entry.Mode)
+	switch {
+	case entry.Mode.IsDir():
+		header.Name += "/"
+		header.Method = zip.Store
+	case entry.Mode&os.ModeSymlink != 0:
+		// Como en Info-ZIP, el destino del enlace se guarda como contenido de la entrada
+		header.Method = zip.Store
+		r = strings.NewReader(entry.Linkname)
+	}
+
+	out, err := w.zw.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	if r != nil && !entry.Mode.IsDir() {
+		_, err = io.Copy(out, r)
+	}
+	return err
+}
+
+func (w *zipBackupWriter) Close() error {
+	return w.zw.Close()
+}
+
+type tarBackupWriter struct {
+	tw      *tar.Writer
+	closers []io.Closer
+}
+
+func (w *tarBackupWriter) Add(entry backupEntry, r io.Reader) error {
+	header := &tar.Header{Name: entry.Name, Mode: int64(entry.Mode.Perm()), ModTime: entry.ModTime}
+	switch {
+	case entry.Mode.IsDir():
+		header.Typeflag = tar.TypeDir
+		header.Name += "/"
+	case entry.Mode&os.ModeSymlink != 0:
+		header.Typeflag = tar.TypeSymlink
+		header.Linkname = entry.Linkname
+	default:
+		header.Typeflag = tar.TypeReg
+		header.Size = entry.Size
+	}
+
+	if err := w.tw.WriteHeader(header); err != nil {
+		return err
+	}
+	if header.Typeflag == tar.TypeReg {
+		_, err := io.Copy(w.tw, r)
+		return err
+	}
+	return nil
+}
+
+func (w *tarBackupWriter) Close() error {
+	err := w.tw.Close()
+	for _, closer := range w.closers {
+		if closeErr := closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// commandCloser espera a que termine un comando externo al cerrar el escritor
+type commandCloser struct {
+	cmd *exec.Cmd
+}
+
+func (c commandCloser) Close() error {
+	if err := c.cmd.Wait(); err != nil {
+		return i18n.Errorf("backup.command_error", c.cmd.Path, err)
+	}
+	return nil
+}
+
+/*
+readBackup recorre las entradas de una copia de seguridad en cualquiera de los formatos
+soportados. Para los archivos regulares, r permite leer su contenido.
+*/
+func readBackup(path string, fn func(entry backupEntry, r io.Reader) error) error {
+	switch backupFormatForPath(path) {
+	case "zip":
+		reader, err := zip.OpenReader(path)
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		return readZipBackup(reader, fn)
+
+	case "tar.gz":
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		return readTarBackup(gz, fn)
+
+	case "tar.zst":
+		cmd := exec.Command("zstd", "-q", "-d", "-c", path)
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			return err
+		}
+		if err := cmd.Start(); err != nil {
+			return i18n.Errorf("backup.zstd_error", err)
+		}
+		err = readTarBackup(stdout, fn)
+		io.Copy(io.Discard, stdout)
+		if waitErr := cmd.Wait(); err == nil && waitErr != nil {
+			err = i18n.Errorf("backup.zstd_decompress_error", waitErr)
+		}
+		return err
+	}
+	return i18n.Errorf("backup.unsupported_file", path)
+}
+
+func readZipBackup(reader *zip.ReadCloser, fn func(entry backupEntry, r io.Reader) error) error {
+	for _, f := range reader.File {
+		entry := backupEntry{
+			// Las copias creadas en Windows por versiones anteriores pueden usar "\"
+			Name:    strings.TrimSuffix(strings.ReplaceAll(f.Name, "\\", "/"), "/"),
+			Mode:    f.Mode(),
+			ModTime: f.Modified,
+			Size:    int64(f.UncompressedSize64),
+		}
+		if f.FileInfo().IsDir() {
+			if err := fn(entry, nil); err != nil {
+				return err
+			}
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		if entry.Mode&os.ModeSymlink != 0 {
+			link, err := io.ReadAll(rc)
+			rc.Close()
+			if err != nil {
+				return err
+			}
+			entry.Linkname = string(link)
+			if err := fn(entry, nil); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// zip verifica el CRC de cada archivo al terminar de leerlo
+		err = fn(entry, rc)
+		rc.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %v", f.Name, err)
+		}
+	}
+	return nil
+}
+
+func readTarBackup(r io.Reader, fn func(entry backupEntry, r io.Reader) error) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		entry := backupEntry{
+			Name:     strings.TrimSuffix(header.Name, "/"),
+			Mode:     os.FileMode(header.Mode).Perm(),
+			Linkname: header.Linkname,
+			ModTime:  header.ModTime,
+			Size:     header.Size,
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			entry.Mode |= os.ModeDir
+			err = fn(entry, nil)
+		case tar.TypeSymlink:
+			entry.Mode |= os.ModeSymlink
+			err = fn(entry, nil)
+		case tar.TypeReg:
+			err = fn(entry, tr)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %v", header.Name, err)
+		}
+	}
+}
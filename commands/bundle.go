@@ -0,0 +1,296 @@
+package commands
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"polynode/i18n"
+	"polynode/pkg/manager"
+	"polynode/shared"
+	"strings"
+	"time"
+)
+
+const bundleManifestName = "bundle.json"
+
+/*
+bundleManifest describe el contenido de un paquete para instalaciones sin conexión.
+El paquete es un archivo tar con la misma estructura que el mirror de Node:
+index.json, v<versión>/SHASUMS256.txt y v<versión>/<archivo de la plataforma>.
+*/
+type bundleManifest struct {
+	Created   time.Time `json:"created"`
+	Versions  []string  `json:"versions"`
+	Platforms []string  `json:"platforms"`
+	Files     []string  `json:"files"`
+}
+
+func ExecuteBundle(args []string) error {
+	if len(args) < 1 {
+		return usageError("cli.command_usage", "bundle <create|import> ...")
+	}
+
+	switch args[0] {
+	case "create":
+		var specs []string
+		platforms := []string{shared.GetPlatform()}
+		output := ""
+		for i := 1; i < len(args); i++ {
+			switch args[i] {
+			case "--versions", "--platforms":
+				if i+1 >= len(args) {
+					return usageError("cli.missing_flag_value", args[i])
+				}
+				values := splitList(args[i+1])
+				if args[i] == "--versions" {
+					specs = values
+				} else {
+					platforms = values
+				}
+				i++
+			default:
+				output = args[i]
+			}
+		}
+		if len(specs) == 0 || output == "" {
+			return usageError("cli.command_usage", placeholders("bundle create --versions <v1,v2,...> [--platforms <p1,p2,...>] <archivo.tar>"))
+		}
+		return createBundle(specs, platforms, output)
+
+	case "import":
+		if len(args) < 2 {
+			return usageError("cli.command_usage", placeholders("bundle import <archivo.tar>"))
+		}
+		return importBundle(args[1])
+	}
+
+	return usageError("cli.unknown_subcommand", "bundle", args[0], "")
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// createBundle descarga (o toma de la caché) los archivos indicados y los agrupa en un único tar
+func createBundle(specs, platforms []string, output string) error {
+	m := newManager(newConsoleReporter(false))
+	if _, err := m.Client(); err != nil {
+		return err
+	}
+
+	manifest := bundleManifest{Created: time.Now().UTC(), Platforms: platforms}
+	for _, spec := range specs {
+		version, err := m.Resolve(spec)
+		if err != nil {
+			return err
+		}
+		if !containsString(manifest.Versions, version) {
+			manifest.Versions = append(manifest.Versions, version)
+		}
+	}
+
+	index, err := m.DownloadIndex()
+	if err != nil {
+		if index, err = os.ReadFile(manager.IndexCachePath()); err != nil {
+			return i18n.Errorf("bundle.index_error", err)
+		}
+	}
+
+	outFile, err := os.Create(output)
+	if err != nil {
+		return i18n.Errorf("bundle.create_error", output, err)
+	}
+	defer outFile.Close()
+
+	tw := tar.NewWriter(outFile)
+	defer tw.Close()
+
+	if err := writeTarBytes(tw, "index.json", index); err != nil {
+		return err
+	}
+
+	for _, version := range manifest.Versions {
+		// SHASUMS256.txt se escribe antes que los archivos para poder verificarlos al importar
+		checksumsBody, err := os.ReadFile(manager.ChecksumsCachePath(version))
+		if err != nil {
+			if checksumsBody, err = m.DownloadChecksums(version); err != nil {
+				return err
+			}
+			manager.SaveChecksums(version, checksumsBody)
+		}
+		checksums := manager.ParseChecksums(strings.NewReader(string(checksumsBody)))
+		if err := writeTarBytes(tw, path.Join("v"+version, manager.ChecksumsFileName), checksumsBody); err != nil {
+			return err
+		}
+
+		for _, platform := range platforms {
+			name := shared.GetArchiveNameFor(version, platform)
+			expectedSHA, ok := checksums[name]
+			if !ok {
+				return i18n.Errorf("bundle.missing_platform", version, platform)
+			}
+
+			archivePath, cached := manager.FindCachedArchive(name, expectedSHA)
+			if !cached {
+				archivePath, err = m.DownloadArchive(version, name, expectedSHA)
+				if err != nil {
+					return err
+				}
+			} else {
+				fmt.Print(i18n.T("bundle.using_cache", name))
+			}
+
+			entryName := path.Join("v"+version, name)
+			if err := writeTarFile(tw, entryName, archivePath); err != nil {
+				return err
+			}
+			manifest.Files = append(manifest.Files, entryName)
+		}
+	}
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "    ")
+	if err != nil {
+		return i18n.Errorf("bundle.manifest_error", err)
+	}
+	if err := writeTarBytes(tw, bundleManifestName, manifestJSON); err != nil {
+		return err
+	}
+
+	fmt.Print(i18n.N("bundle.created", len(manifest.Files), output, len(manifest.Files), strings.Join(manifest.Versions, ", ")))
+	return nil
+}
+
+/*
+importBundle copia el contenido de un paquete a la caché del espacio de trabajo (archivos,
+SHASUMS256.txt e index.json), de modo que "poly install" funcione sin conexión.
+*/
+func importBundle(bundlePath string) error {
+	file, err := os.Open(bundlePath)
+	if err != nil {
+		return i18n.Errorf("bundle.open_error", bundlePath, err)
+	}
+	defer file.Close()
+
+	checksums := map[string]map[string]string{}
+	imported := 0
+	tr := tar.NewReader(file)
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return i18n.Errorf("bundle.read_error", bundlePath, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		dir, name := path.Split(header.Name)
+		version := strings.TrimPrefix(strings.TrimSuffix(dir, "/"), "v")
+		if version != "" {
+			// El nombre del directorio acaba formando rutas de la caché
+			if _, err := manager.ParseVersion(version); err != nil {
+				return i18n.Errorf("bundle.invalid_version", header.Name, err)
+			}
+		}
+
+		switch {
+		case header.Name == bundleManifestName:
+			continue
+
+		case header.Name == "index.json":
+			body, err := io.ReadAll(tr)
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(shared.GetCachePath(), os.ModePerm); err != nil {
+				return err
+			}
+			if err := os.WriteFile(manager.IndexCachePath(), body, 0644); err != nil {
+				return i18n.Errorf("bundle.save_index_error", err)
+			}
+
+		case name == manager.ChecksumsFileName && version != "":
+			body, err := io.ReadAll(tr)
+			if err != nil {
+				return err
+			}
+			if err := manager.SaveChecksums(version, body); err != nil {
+				return err
+			}
+			checksums[version] = manager.ParseChecksums(strings.NewReader(string(body)))
+
+		case version != "":
+			// Cada archivo debe llegar después de su SHASUMS y aparecer en él
+			versionChecksums, ok := checksums[version]
+			if !ok {
+				return withCode(ErrorCodeIntegrity, i18n.Errorf("bundle.missing_checksums", header.Name, manager.ChecksumsFileName))
+			}
+			expected, ok := versionChecksums[name]
+			if !ok {
+				return withCode(ErrorCodeIntegrity, i18n.Errorf("bundle.unlisted_file", header.Name, manager.ChecksumsFileName))
+			}
+			if _, err := manager.StoreInCache(tr, name, expected); err != nil {
+				return err
+			}
+			fmt.Print(i18n.T("bundle.imported_file", name))
+			imported++
+		}
+	}
+
+	fmt.Print(i18n.N("bundle.imported", imported, imported))
+	return nil
+}
+
+func writeTarBytes(tw *tar.Writer, name string, data []byte) error {
+	header := &tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), ModTime: time.Now()}
+	if err := tw.WriteHeader(header); err != nil {
+		return i18n.Errorf("bundle.write_error", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return i18n.Errorf("bundle.write_error", name, err)
+	}
+	return nil
+}
+
+func writeTarFile(tw *tar.Writer, name, source string) error {
+	file, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	header := &tar.Header{Name: name, Mode: 0644, Size: info.Size(), ModTime: info.ModTime()}
+	if err := tw.WriteHeader(header); err != nil {
+		return i18n.Errorf("bundle.write_error", name, err)
+	}
+	if _, err := io.Copy(tw, file); err != nil {
+		return i18n.Errorf("bundle.write_error", name, err)
+	}
+	return nil
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
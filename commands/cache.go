@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"fmt"
+	"polynode/i18n"
+	"polynode/pkg/manager"
+	"polynode/shared"
+	"time"
+)
+
+func ExecuteCache(args []string) error {
+	if len(args) < 1 {
+		return usageError("cli.command_usage", placeholders("cache <list|clean> [--older-than <duración>]"))
+	}
+
+	switch args[0] {
+	case "list":
+		return listCache()
+
+	case "clean":
+		var olderThan time.Duration
+		if len(args) >= 3 && args[1] == "--older-than" {
+			duration, err := shared.ParseDuration(args[2])
+			if err != nil {
+				return err
+			}
+			olderThan = duration
+		} else if len(args) > 1 {
+			return usageError("cli.command_usage", placeholders("cache clean [--older-than <duración>]"))
+		}
+
+		removed, freed, err := manager.CleanCache(olderThan)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(messageOutput(), i18n.N("cache.cleaned", removed, removed, shared.FormatSize(freed)))
+		return nil
+	}
+
+	return usageError("cli.unknown_subcommand", "cache", args[0], "")
+}
+
+// cacheListOutput es la salida estructurada de "poly cache list"; los tamaños están en bytes
+type cacheListOutput struct {
+	Archives  []cachedArchiveOutput `json:"archives"`
+	TotalSize int64                 `json:"total_size"`
+	MaxSize   string                `json:"max_size"`
+}
+
+type cachedArchiveOutput struct {
+	Name     string    `json:"name"`
+	SHA256   string    `json:"sha256"`
+	Size     int64     `json:"size"`
+	LastUsed time.Time `json:"last_used"`
+	Path     string    `json:"path"`
+}
+
+func listCache() error {
+	archives, err := manager.ListCachedArchives()
+	if err != nil {
+		return err
+	}
+
+	if StructuredOutput() {
+		output := cacheListOutput{Archives: []cachedArchiveOutput{}, MaxSize: shared.GetConfig("cache_max_size")}
+		for _, archive := range archives {
+			output.TotalSize += archive.Size
+			output.Archives = append(output.Archives, cachedArchiveOutput{
+				Name:     archive.Name,
+				SHA256:   archive.SHA256,
+				Size:     archive.Size,
+				LastUsed: archive.LastUsed,
+				Path:     archive.Path,
+			})
+		}
+		return writeOutput(output)
+	}
+
+	if len(archives) == 0 {
+		fmt.Println(i18n.T("cache.empty"))
+		return nil
+	}
+
+	var total int64
+	fmt.Println(i18n.T("cache.title"))
+	for _, archive := range archives {
+		total += archive.Size
+		fmt.Printf(" - %-32s %10s  %s  %s\n", archive.Name, shared.FormatSize(archive.Size), archive.LastUsed.Format("2006-01-02 15:04"), archive.SHA256[:12])
+	}
+	fmt.Print(i18n.T("cache.total", shared.FormatSize(total), shared.GetConfig("cache_max_size")))
+	return nil
+}
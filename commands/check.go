@@ -5,10 +5,27 @@ import (
 	"fmt"
 	"os/exec"
 	"path/filepath"
+	"polynode/i18n"
 	"polynode/shared"
 	"strings"
 )
 
+// Resultados de la verificación del PATH
+const (
+	checkStatusOK       = "ok"
+	checkStatusNotFound = "not-found"
+	checkStatusMismatch = "mismatch"
+	checkStatusMultiple = "multiple"
+)
+
+// checkOutput es la salida estructurada de "poly check"; Fix es el comando sugerido para corregir el PATH
+type checkOutput struct {
+	ExpectedPath string   `json:"expected_path"`
+	NodePaths    []string `json:"node_paths"`
+	Status       string   `json:"status"`
+	Fix          *string  `json:"fix"`
+}
+
 func CheckInstallation() error {
 	currentPath := shared.GetCurrentVersionPath()
 
@@ -25,11 +42,11 @@ func CheckInstallation() error {
 	cmd := exec.Command(whereCmd.Path, "node")
 	output, err := cmd.StdoutPipe()
 	if err != nil {
-		return fmt.Errorf("Error al ejecutar 'where node': %w", err)
+		return i18n.Errorf("check.where_error", err)
 	}
 
 	if err := cmd.Start(); err != nil {
-		return fmt.Errorf("Error al iniciar el comando 'where node': %w", err)
+		return i18n.Errorf("check.where_start_error", err)
 	}
 
 	// Leer la salida del comando línea por línea
@@ -40,35 +57,52 @@ func CheckInstallation() error {
 	}
 
 	if err := cmd.Wait(); err != nil {
-		return fmt.Errorf("Error al esperar el comando 'where node': %w", err)
+		return i18n.Errorf("check.where_wait_error", err)
 	}
 
 	// Verificar la lista de ubicaciones del ejecutable de Node.js
-	switch len(nodePaths) {
-	case 0:
-		// Si no hay elementos en la lista, indicar cómo actualizar el PATH
-		fmt.Println("No se encontraron ubicaciones para el ejecutable de Node.js.")
-		fmt.Println("Por favor, actualice el PATH del sistema con el siguiente comando:")
-		fmt.Printf("SET PATH=%s;%%PATH%%\n", currentPath)
-	case 1:
-		// Si hay una sola ubicación, verificar si coincide con la ubicación esperada
-		if nodePaths[0] != filepath.Join(currentPath, "node.exe") {
-			fmt.Println("La ubicación del ejecutable de Node.js no coincide con la ubicación esperada.")
-			fmt.Println("Por favor, actualice el PATH del sistema con el siguiente comando:")
-			fmt.Printf("SET PATH=%s;%%PATH%%\n", currentPath)
-		} else {
-			fmt.Println("La ubicación del ejecutable de Node.js coincide con la ubicación esperada.")
+	expected := filepath.Join(currentPath, "node.exe")
+	status := checkStatusOK
+	switch {
+	case len(nodePaths) == 0:
+		status = checkStatusNotFound
+	case nodePaths[0] != expected:
+		status = checkStatusMismatch
+	case len(nodePaths) > 1:
+		status = checkStatusMultiple
+	}
+	fixCommand := fmt.Sprintf("SET PATH=%s;%%PATH%%", currentPath)
+
+	if StructuredOutput() {
+		output := checkOutput{ExpectedPath: expected, NodePaths: nodePaths, Status: status}
+		if output.NodePaths == nil {
+			output.NodePaths = []string{}
+		}
+		if status == checkStatusNotFound || status == checkStatusMismatch {
+			output.Fix = &fixCommand
 		}
-	default:
-		// Si hay más de una ubicación, verificar si la primera coincide con la ubicación esperada
-		if nodePaths[0] != filepath.Join(currentPath, "node.exe") {
-			fmt.Println("La primera ubicación del ejecutable de Node.js no coincide con la ubicación esperada.")
-			fmt.Println("Por favor, actualice el PATH del sistema con el siguiente comando:")
-			fmt.Printf("SET PATH=%s;%%PATH%%\n", currentPath)
+		return writeOutput(output)
+	}
+
+	switch status {
+	case checkStatusNotFound:
+		// Si no hay elementos en la lista, indicar cómo actualizar el PATH
+		fmt.Println(i18n.T("check.not_found"))
+		fmt.Println(i18n.T("check.fix_hint"))
+		fmt.Println(fixCommand)
+	case checkStatusMismatch:
+		if len(nodePaths) == 1 {
+			fmt.Println(i18n.T("check.mismatch"))
 		} else {
-			fmt.Println("La primera ubicación del ejecutable de Node.js coincide con la ubicación esperada.")
-			fmt.Println("Se encontraron múltiples ubicaciones del ejecutable de Node. Se recomienda eliminar las ubicaciones adicionales del PATH correspondientes a otras instalaciones de Node para evitar conflictos.")
+			fmt.Println(i18n.T("check.first_mismatch"))
 		}
+		fmt.Println(i18n.T("check.fix_hint"))
+		fmt.Println(fixCommand)
+	case checkStatusOK:
+		fmt.Println(i18n.T("check.ok"))
+	case checkStatusMultiple:
+		fmt.Println(i18n.T("check.first_ok"))
+		fmt.Println(i18n.T("check.multiple"))
 	}
 
 	return nil
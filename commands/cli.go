@@ -0,0 +1,503 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"polynode/i18n"
+	"polynode/pkg/manager"
+	"polynode/shared"
+	"strconv"
+	"strings"
+)
+
+/*
+Códigos de salida de poly. Forman parte de la interfaz del programa (los usan los scripts de CI),
+por lo que no deben cambiar.
+*/
+const (
+	ExitOK         = 0
+	ExitError      = 1
+	ExitUsage      = 2
+	ExitNotFound   = 3
+	ExitNetwork    = 4
+	ExitIntegrity  = 5
+	ExitEOL        = 6
+	ExitVulnerable = 7
+)
+
+/*
+Flag describe una opción de un comando para la ayuda y el análisis de la línea de comandos.
+La descripción está en el catálogo de mensajes, con la clave "cmd.<comando>.flag.<nombre>"
+("global.flag.<nombre>" para las opciones globales).
+*/
+type Flag struct {
+	// Name es el nombre largo, por ejemplo "--output"
+	Name string
+	// Short es el nombre corto opcional, por ejemplo "-y"
+	Short string
+	// Value describe el valor que recibe la opción ("<directorio>"); vacío si no recibe valor
+	Value string
+	// Values son los valores posibles de la opción, para el autocompletado
+	Values []string
+}
+
+/*
+Command es un nodo del árbol de comandos de poly. Un comando con subcomandos y sin Run
+sólo agrupa a sus subcomandos (por ejemplo "cache"); si tiene Run, los argumentos que no
+son un subcomando se pasan a Run (por ejemplo "backup" y "backup list"). Los textos de la
+ayuda están en el catálogo de mensajes, con las claves "cmd.<comando>.summary" y, si el
+comando tiene una descripción más extensa, "cmd.<comando>.description".
+*/
+type Command struct {
+	Name string
+	// Args describe los parámetros posicionales en la ayuda, por ejemplo "<version>"
+	Args string
+	// Flags son las opciones propias del comando; las analiza Run
+	Flags       []Flag
+	Subcommands []*Command
+	// Structured indica que el comando admite --output json|yaml
+	Structured bool
+	Run        func(args []string) error
+	// Complete devuelve los valores posibles del siguiente parámetro posicional, dados los anteriores
+	Complete func(args []string) []string
+
+	parent *Command
+}
+
+// globalOptions son los valores de las opciones globales de la línea de comandos
+type globalOptions struct {
+	Workspace string
+	Quiet     bool
+	Verbose   bool
+	NoColor   bool
+	Yes       bool
+	Help      bool
+}
+
+var cliOptions globalOptions
+
+// globalFlags son las opciones que se aceptan en cualquier posición y para cualquier comando
+var globalFlags = []Flag{
+	{Name: "--workspace", Value: "<directorio>"},
+	{Name: "--output", Value: "<json|yaml|text>", Values: []string{OutputJSON, OutputYAML, OutputText}},
+	{Name: "--json"},
+	{Name: "--quiet", Short: "-q"},
+	{Name: "--verbose"},
+	{Name: "--no-color"},
+	{Name: "--yes", Short: "-y"},
+	{Name: "--help", Short: "-h"},
+}
+
+// Quiet indica si se pidió mostrar sólo errores y advertencias (--quiet)
+func Quiet() bool {
+	return cliOptions.Quiet
+}
+
+// Verbose indica si se pidió información adicional para diagnóstico (--verbose)
+func Verbose() bool {
+	return cliOptions.Verbose
+}
+
+// AssumeYes indica si se deben aceptar las confirmaciones sin preguntar (--yes)
+func AssumeYes() bool {
+	return cliOptions.Yes
+}
+
+// ansiEnabled indica si se pueden usar secuencias de escape ANSI (--no-color, NO_COLOR o TERM=dumb las desactivan)
+func ansiEnabled() bool {
+	return !cliOptions.NoColor && os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb"
+}
+
+// printInfo muestra el mensaje informativo del catálogo indicado por key, salvo con --quiet
+func printInfo(key string, args ...interface{}) {
+	fmt.Fprint(messageOutput(), i18n.T(key, args...))
+}
+
+// debugf muestra el mensaje de diagnóstico del catálogo indicado por key en stderr cuando se indicó --verbose
+func debugf(key string, args ...interface{}) {
+	if cliOptions.Verbose {
+		fmt.Fprintln(os.Stderr, "[debug] "+i18n.T(key, args...))
+	}
+}
+
+/*
+Execute interpreta la línea de comandos, ejecuta el comando indicado y devuelve el código de
+salida del programa.
+*/
+func Execute(args []string) int {
+	root := newRootCommand()
+
+	// El autocompletado recibe la línea de comandos sin interpretar
+	if len(args) > 0 && args[0] == completeCommand {
+		return executeComplete(root, args[1:])
+	}
+
+	// El idioma se vuelve a seleccionar después de leer config.json
+	i18n.SetLanguage("")
+
+	args, err := parseConfigFlags(args)
+	if err != nil {
+		return fail(err)
+	}
+	i18n.SetLanguage(shared.GetConfig("lang"))
+
+	command, rest, err := parseCommandLine(root, args)
+	if err != nil {
+		return fail(err)
+	}
+
+	if cliOptions.Workspace != "" {
+		workspace, err := filepath.Abs(cliOptions.Workspace)
+		if err != nil {
+			return fail(i18n.Errorf("cli.invalid_workspace", err))
+		}
+		shared.SetInstallPath(workspace)
+	}
+	if err := shared.LoadConfig(); err != nil {
+		return fail(err)
+	}
+	i18n.SetLanguage(shared.GetConfig("lang"))
+	for _, warning := range shared.ConfigWarnings() {
+		fmt.Fprintln(os.Stderr, warning)
+	}
+
+	if cliOptions.Help || command == root {
+		showCommandHelp(command)
+		return ExitOK
+	}
+
+	if StructuredOutput() && !command.Structured {
+		return fail(UnsupportedOutputError(command.Path()))
+	}
+
+	if err := prepareWorkspace(); err != nil {
+		return fail(err)
+	}
+
+	mirror, source := shared.GetConfigValue("mirror")
+	debugf("cli.debug.workspace", shared.GetInstallPath())
+	debugf("cli.debug.config", shared.GetConfigFilePath())
+	if shared.GetProjectConfigPath() != "" {
+		debugf("cli.debug.project_config", shared.GetProjectConfigPath())
+	}
+	debugf("cli.debug.mirror", mirror, source)
+	debugf("cli.debug.language", i18n.Language())
+
+	if err := command.Run(rest); err != nil {
+		return fail(err)
+	}
+	return ExitOK
+}
+
+// fail muestra un error y devuelve el código de salida que corresponde a su categoría
+func fail(err error) int {
+	PrintError(err)
+	code := exitCode(err)
+	debugf("cli.debug.exit_code", code, errorCode(err))
+	return code
+}
+
+// exitCode devuelve el código de salida de un error según su categoría
+func exitCode(err error) int {
+	switch {
+	case errors.Is(err, manager.ErrNotFound):
+		return ExitNotFound
+	case errors.Is(err, manager.ErrNetwork):
+		return ExitNetwork
+	case errors.Is(err, manager.ErrIntegrity):
+		return ExitIntegrity
+	}
+
+	switch errorCode(err) {
+	case ErrorCodeUsage, ErrorCodeUnsupported:
+		return ExitUsage
+	case ErrorCodeNotFound:
+		return ExitNotFound
+	case ErrorCodeNetwork:
+		return ExitNetwork
+	case ErrorCodeIntegrity:
+		return ExitIntegrity
+	case ErrorCodeEOL:
+		return ExitEOL
+	case ErrorCodeVulnerable:
+		return ExitVulnerable
+	}
+	return ExitError
+}
+
+// prepareWorkspace crea las carpetas del espacio de trabajo si todavía no existen
+func prepareWorkspace() error {
+	for _, path := range []string{shared.GetInstallPath(), shared.GetRepoPath()} {
+		if err := os.MkdirAll(path, 0755); err != nil {
+			return i18n.Errorf("cli.workspace_error", err)
+		}
+	}
+	return nil
+}
+
+/*
+parseCommandLine recorre los argumentos buscando el comando (y subcomando) a ejecutar y las
+opciones globales, que se aceptan en cualquier posición. Si el comando declara una opción con
+el mismo nombre que una global (por ejemplo --output en "backup"), prevalece la del comando.
+Devuelve el comando y los argumentos restantes, que se pasan a su función Run.
+*/
+func parseCommandLine(root *Command, args []string) (*Command, []string, error) {
+	command := root
+	var rest []string
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		if strings.HasPrefix(arg, "-") {
+			name, value, hasValue := strings.Cut(arg, "=")
+			if flag, ok := lookupFlag(globalFlags, name); ok && !command.hasFlag(name) {
+				if flag.Value != "" && !hasValue {
+					if i+1 >= len(args) {
+						return nil, nil, usageError("cli.missing_flag_value", flag.Name)
+					}
+					i++
+					value = args[i]
+				}
+				if err := setGlobalFlag(flag, value); err != nil {
+					return nil, nil, err
+				}
+				continue
+			}
+			if command == root {
+				return nil, nil, usageError("cli.unknown_option", name, suggestion(name, flagNames(globalFlags)))
+			}
+			rest = append(rest, arg)
+			continue
+		}
+
+		if len(rest) == 0 && len(command.Subcommands) > 0 {
+			if sub := command.subcommand(arg); sub != nil {
+				command = sub
+				continue
+			}
+			if command.Run == nil {
+				if command == root {
+					return nil, nil, usageError("cli.unknown_command", arg, suggestion(arg, command.subcommandNames()))
+				}
+				return nil, nil, usageError("cli.unknown_subcommand", command.Path(), arg, suggestion(arg, command.subcommandNames()))
+			}
+		}
+		rest = append(rest, arg)
+	}
+
+	if command != root && command.Run == nil && !cliOptions.Help {
+		return nil, nil, usageError("cli.subcommand_usage", command.Path(), strings.Join(command.subcommandNames(), "|"))
+	}
+	return command, rest, nil
+}
+
+func setGlobalFlag(flag Flag, value string) error {
+	switch flag.Name {
+	case "--workspace":
+		cliOptions.Workspace = value
+	case "--output":
+		return SetOutputFormat(value)
+	case "--json":
+		return SetOutputFormat(OutputJSON)
+	case "--quiet":
+		cliOptions.Quiet = true
+	case "--verbose":
+		cliOptions.Verbose = true
+	case "--no-color":
+		cliOptions.NoColor = true
+	case "--yes":
+		cliOptions.Yes = true
+	case "--help":
+		cliOptions.Help = true
+	}
+	return nil
+}
+
+/*
+parseConfigFlags extrae de los argumentos los flags que sobrescriben claves de configuración
+(por ejemplo --mirror=<url> o --arch arm64) y devuelve el resto de los argumentos.
+*/
+func parseConfigFlags(args []string) ([]string, error) {
+	var remaining []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "--") {
+			remaining = append(remaining, arg)
+			continue
+		}
+
+		flagName, value, hasValue := strings.Cut(arg, "=")
+		var key shared.ConfigKey
+		found := false
+		for _, candidate := range shared.ConfigKeys() {
+			if candidate.FlagName() == flagName {
+				key, found = candidate, true
+				break
+			}
+		}
+		if !found {
+			remaining = append(remaining, arg)
+			continue
+		}
+
+		if !hasValue && key.Bool {
+			// Un flag booleano sólo toma el siguiente argumento si es true o false
+			value = "true"
+			if i+1 < len(args) {
+				if _, err := strconv.ParseBool(args[i+1]); err == nil {
+					i++
+					value = args[i]
+				}
+			}
+		} else if !hasValue {
+			if i+1 >= len(args) {
+				return nil, usageError("cli.missing_flag_value", flagName)
+			}
+			i++
+			value = args[i]
+		}
+
+		if err := shared.SetFlagValue(key.Name, value); err != nil {
+			return nil, withCode(ErrorCodeUsage, err)
+		}
+	}
+	return remaining, nil
+}
+
+// Summary devuelve la descripción breve del comando en el idioma seleccionado
+func (c *Command) Summary() string {
+	return i18n.T("cmd." + c.key() + ".summary")
+}
+
+// Description devuelve la descripción extensa del comando, o una cadena vacía si no tiene
+func (c *Command) Description() string {
+	key := "cmd." + c.key() + ".description"
+	if !i18n.Has(key) {
+		return ""
+	}
+	return i18n.T(key)
+}
+
+// flagUsage devuelve la descripción de una opción del comando en el idioma seleccionado
+func (c *Command) flagUsage(flag Flag) string {
+	return i18n.T("cmd." + c.key() + ".flag." + strings.TrimPrefix(flag.Name, "--"))
+}
+
+// key devuelve el identificador del comando en el catálogo de mensajes, por ejemplo "cache.clean"
+func (c *Command) key() string {
+	return strings.ReplaceAll(c.Path(), " ", ".")
+}
+
+// Path devuelve el nombre completo del comando sin "poly", por ejemplo "cache clean"
+func (c *Command) Path() string {
+	if c.parent == nil || c.parent.parent == nil {
+		return c.Name
+	}
+	return c.parent.Path() + " " + c.Name
+}
+
+func (c *Command) subcommand(name string) *Command {
+	for _, sub := range c.Subcommands {
+		if sub.Name == name {
+			return sub
+		}
+	}
+	return nil
+}
+
+func (c *Command) subcommandNames() []string {
+	var names []string
+	for _, sub := range c.Subcommands {
+		names = append(names, sub.Name)
+	}
+	return names
+}
+
+func (c *Command) hasFlag(name string) bool {
+	_, ok := lookupFlag(c.Flags, name)
+	return ok
+}
+
+// link completa las referencias al comando padre en todo el árbol
+func (c *Command) link() *Command {
+	for _, sub := range c.Subcommands {
+		sub.parent = c
+		sub.link()
+	}
+	return c
+}
+
+func lookupFlag(flags []Flag, name string) (Flag, bool) {
+	for _, flag := range flags {
+		if flag.Name == name || (flag.Short != "" && flag.Short == name) {
+			return flag, true
+		}
+	}
+	return Flag{}, false
+}
+
+func flagNames(flags []Flag) []string {
+	var names []string
+	for _, flag := range flags {
+		names = append(names, flag.Name)
+	}
+	return names
+}
+
+// suggestion arma la sugerencia que acompaña al mensaje de un comando u opción desconocidos
+func suggestion(name string, candidates []string) string {
+	message := ""
+	if suggested := suggest(name, candidates); suggested != "" {
+		message = i18n.T("cli.did_you_mean", suggested)
+	}
+	return message + i18n.T("cli.see_help")
+}
+
+// suggest devuelve el candidato más parecido a name, o una cadena vacía si ninguno es suficientemente parecido
+func suggest(name string, candidates []string) string {
+	best, bestDistance := "", 0
+	for _, candidate := range candidates {
+		distance := levenshtein(name, candidate)
+		if strings.HasPrefix(candidate, name) && len(name) >= 2 {
+			distance = 1
+		}
+		if best == "" || distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+
+	// Se admite hasta un tercio del nombre con errores (al menos uno)
+	maxDistance := len(name) / 3
+	if maxDistance < 1 {
+		maxDistance = 1
+	}
+	if best == "" || bestDistance > maxDistance {
+		return ""
+	}
+	return best
+}
+
+// levenshtein calcula la distancia de edición entre dos textos
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current := make([]int, len(rb)+1)
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(rb)]
+}
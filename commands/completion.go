@@ -0,0 +1,291 @@
+package commands
+
+import (
+	"fmt"
+	"polynode/pkg/manager"
+	"polynode/shared"
+	"sort"
+	"strings"
+)
+
+// completeCommand es el comando oculto que usan los scripts de autocompletado
+const completeCommand = "__complete"
+
+// completionScripts son los scripts de autocompletado de cada shell; todos delegan en "poly __complete"
+var completionScripts = map[string]string{
+	"bash":       bashCompletion,
+	"zsh":        zshCompletion,
+	"fish":       fishCompletion,
+	"powershell": powershellCompletion,
+}
+
+// completionShells devuelve los shells para los que se puede generar el autocompletado
+func completionShells() []string {
+	return []string{"bash", "zsh", "fish", "powershell"}
+}
+
+// ExecuteCompletion muestra el script de autocompletado del shell indicado
+func ExecuteCompletion(args []string) error {
+	if len(args) != 1 {
+		return usageError("cli.command_usage", "completion <"+strings.Join(completionShells(), "|")+">")
+	}
+	script, ok := completionScripts[args[0]]
+	if !ok {
+		return usageError("completion.unknown_shell", args[0], strings.Join(completionShells(), ", "))
+	}
+	fmt.Print(script)
+	return nil
+}
+
+/*
+executeComplete muestra, una por línea, las opciones de autocompletado de la línea de comandos.
+El último argumento es la palabra que se está completando (vacía si el cursor está después de
+un espacio). No muestra errores: si no hay opciones, el shell completa con nombres de archivo.
+*/
+func executeComplete(root *Command, words []string) int {
+	shared.LoadConfig()
+
+	for _, candidate := range completeWords(root, words) {
+		fmt.Println(candidate)
+	}
+	return ExitOK
+}
+
+// completeWords devuelve las opciones para la última palabra de words, que sigue a las anteriores
+func completeWords(root *Command, words []string) []string {
+	current := ""
+	if len(words) > 0 {
+		current = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+	// PowerShell no puede pasar un argumento vacío a un programa externo en todas sus versiones
+	if current == `""` {
+		current = ""
+	}
+
+	command := root
+	var positional []string
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		if strings.HasPrefix(word, "-") {
+			name, _, hasValue := strings.Cut(word, "=")
+			if flag, ok := completionFlag(command, name); ok && flag.Value != "" && !hasValue {
+				if i == len(words)-1 {
+					return filterCandidates(flag.Values, current)
+				}
+				i++
+			}
+			continue
+		}
+		if len(positional) == 0 {
+			if sub := command.subcommand(word); sub != nil {
+				command = sub
+				continue
+			}
+		}
+		positional = append(positional, word)
+	}
+
+	var candidates []string
+	if strings.HasPrefix(current, "-") {
+		for _, flag := range command.Flags {
+			candidates = append(candidates, flag.Name)
+		}
+		for _, flag := range globalFlags {
+			if !command.hasFlag(flag.Name) {
+				candidates = append(candidates, flag.Name)
+			}
+		}
+		for _, key := range shared.ConfigKeys() {
+			candidates = append(candidates, key.FlagName())
+		}
+		return filterCandidates(candidates, current)
+	}
+
+	if len(positional) == 0 {
+		candidates = append(candidates, command.subcommandNames()...)
+	}
+	if command.Complete != nil {
+		candidates = append(candidates, command.Complete(positional)...)
+	}
+	return filterCandidates(candidates, current)
+}
+
+// completionFlag busca una opción del comando, global o de configuración por su nombre
+func completionFlag(command *Command, name string) (Flag, bool) {
+	if flag, ok := lookupFlag(command.Flags, name); ok {
+		return flag, true
+	}
+	if flag, ok := lookupFlag(globalFlags, name); ok {
+		return flag, true
+	}
+	for _, key := range shared.ConfigKeys() {
+		if key.FlagName() == name && key.Bool {
+			return Flag{Name: name}, true
+		}
+		if key.FlagName() == name {
+			return Flag{Name: name, Value: "<valor>"}, true
+		}
+	}
+	return Flag{}, false
+}
+
+// filterCandidates devuelve las opciones que empiezan con prefix, sin repetir
+func filterCandidates(candidates []string, prefix string) []string {
+	seen := map[string]bool{}
+	var filtered []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, prefix) && !seen[candidate] {
+			seen[candidate] = true
+			filtered = append(filtered, candidate)
+		}
+	}
+	return filtered
+}
+
+// completeFirst adapta una función de autocompletado para que sólo complete el primer parámetro
+func completeFirst(complete func() []string) func([]string) []string {
+	return func(args []string) []string {
+		if len(args) > 0 {
+			return nil
+		}
+		return complete()
+	}
+}
+
+// completeEach adapta una función de autocompletado para que complete todos los parámetros
+func completeEach(complete func() []string) func([]string) []string {
+	return func(args []string) []string {
+		return complete()
+	}
+}
+
+// completeInstalledVersions devuelve las versiones instaladas en el repositorio local y los alias
+func completeInstalledVersions() []string {
+	versions, _ := manager.ListInstalledVersions()
+	return append(versions, completeAliases()...)
+}
+
+// completeAliasSet completa el nombre de un alias existente y luego la versión a la que apunta
+func completeAliasSet(args []string) []string {
+	switch len(args) {
+	case 0:
+		return completeAliases()
+	case 1:
+		return completeRemoteVersions()
+	}
+	return nil
+}
+
+// completeRemoteVersions devuelve las versiones del índice guardado en la caché, de la más nueva a la más antigua
+func completeRemoteVersions() []string {
+	index, err := manager.CachedIndex()
+	if err != nil {
+		return append([]string{"lts"}, completeAliases()...)
+	}
+
+	var versions []shared.Version
+	for _, entry := range index {
+		if version, err := manager.ParseVersion(shared.NormalizeVersion(entry.Version)); err == nil {
+			versions = append(versions, version)
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return manager.CompareVersions(versions[i], versions[j]) > 0
+	})
+
+	candidates := append([]string{"lts"}, completeAliases()...)
+	for _, version := range versions {
+		candidates = append(candidates, fmt.Sprintf("%d.%d.%d", version.Major, version.Minor, version.Patch))
+	}
+	return candidates
+}
+
+// completeBackups devuelve las copias de seguridad del directorio de copias
+func completeBackups() []string {
+	backups, _ := findBackups(getBackupDir())
+	var paths []string
+	for _, backup := range backups {
+		paths = append(paths, backup.Path)
+	}
+	return paths
+}
+
+// completeConfigKeys devuelve los nombres de las claves de configuración
+func completeConfigKeys() []string {
+	var names []string
+	for _, key := range shared.ConfigKeys() {
+		names = append(names, key.Name)
+	}
+	return names
+}
+
+const bashCompletion = `# Autocompletado de poly para bash
+# Uso: source <(poly completion bash)
+
+_poly() {
+    local IFS=$'\n'
+    COMPREPLY=($(poly __complete "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null))
+}
+
+complete -o default -F _poly poly
+`
+
+const zshCompletion = `#compdef poly
+# Autocompletado de poly para zsh
+# Uso: poly completion zsh > "${fpath[1]}/_poly"
+
+_poly() {
+    local -a candidates
+    candidates=("${(@f)$(poly __complete "${(@)words[2,CURRENT]}" 2>/dev/null)}")
+    candidates=(${candidates:#})
+    if (( ${#candidates} )); then
+        compadd -a candidates
+    else
+        _files
+    fi
+}
+
+if [ "$funcstack[1]" = "_poly" ]; then
+    _poly "$@"
+else
+    compdef _poly poly
+fi
+`
+
+const fishCompletion = `# Autocompletado de poly para fish
+# Uso: poly completion fish > ~/.config/fish/completions/poly.fish
+
+function __poly_complete
+    set -l tokens (commandline -opc)
+    set -e tokens[1]
+    set -l candidates (poly __complete $tokens (commandline -ct) 2>/dev/null)
+    if test (count $candidates) -eq 0
+        __fish_complete_path (commandline -ct)
+        return
+    end
+    printf '%s\n' $candidates
+end
+
+complete -c poly -f -a '(__poly_complete)'
+`
+
+const powershellCompletion = `# Autocompletado de poly para PowerShell
+# Uso: poly completion powershell | Out-String | Invoke-Expression
+
+Register-ArgumentCompleter -Native -CommandName poly -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+
+    $words = @($commandAst.CommandElements |
+        Select-Object -Skip 1 |
+        Where-Object { $_.Extent.EndOffset -le $cursorPosition } |
+        ForEach-Object { $_.ToString() })
+    if ($wordToComplete -eq '') {
+        $words += '""'
+    }
+
+    poly __complete @words 2>$null | Where-Object { $_ -ne '' } | ForEach-Object {
+        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+    }
+}
+`
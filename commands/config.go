@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"fmt"
+	"polynode/i18n"
+	"polynode/shared"
+)
+
+func ExecuteConfig(args []string) error {
+	if len(args) < 1 {
+		return usageError("cli.command_usage", placeholders("config <get|set|list|unset> [clave] [valor]"))
+	}
+
+	switch args[0] {
+	case "list":
+		listConfig()
+		return nil
+
+	case "get":
+		if len(args) < 2 {
+			return usageError("cli.command_usage", placeholders("config get <clave>"))
+		}
+		if _, ok := shared.LookupConfigKey(args[1]); !ok {
+			return i18n.Errorf("config.unknown_key", args[1])
+		}
+		fmt.Println(shared.GetConfig(args[1]))
+		return nil
+
+	case "set":
+		if len(args) < 3 {
+			return usageError("cli.command_usage", placeholders("config set <clave> <valor>"))
+		}
+		if err := shared.SetUserConfigValue(args[1], args[2]); err != nil {
+			return err
+		}
+		fmt.Print(i18n.T("config.updated", args[1], args[2]))
+		return nil
+
+	case "unset":
+		if len(args) < 2 {
+			return usageError("cli.command_usage", placeholders("config unset <clave>"))
+		}
+		if err := shared.UnsetUserConfigValue(args[1]); err != nil {
+			return err
+		}
+		fmt.Print(i18n.T("config.removed", args[1]))
+		return nil
+	}
+
+	return usageError("cli.unknown_subcommand", "config", args[0], "")
+}
+
+func listConfig() {
+	fmt.Print(i18n.T("config.file", shared.GetConfigFilePath()))
+	if projectPath := shared.GetProjectConfigPath(); projectPath != "" {
+		fmt.Print(i18n.T("config.project_file", projectPath))
+	}
+	fmt.Println()
+
+	for _, key := range shared.ConfigKeys() {
+		value, source := shared.GetConfigValue(key.Name)
+		fmt.Printf(" %-14s = %-30s (%s)\n", key.Name, value, source)
+		fmt.Printf(" %-14s   %s [%s, %s]\n", "", key.Description(), key.EnvName(), key.FlagName())
+	}
+}
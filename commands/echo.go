@@ -0,0 +1,19 @@
+//go:build !windows
+
+package commands
+
+import (
+	"os"
+	"os/exec"
+)
+
+// setEcho activa o desactiva el eco de la terminal de la entrada estándar con stty
+func setEcho(enabled bool) error {
+	mode := "-echo"
+	if enabled {
+		mode = "echo"
+	}
+	cmd := exec.Command("stty", mode)
+	cmd.Stdin = os.Stdin
+	return cmd.Run()
+}
@@ -0,0 +1,29 @@
+package commands
+
+import (
+	"os"
+	"syscall"
+)
+
+// enableEchoInput es el indicador ENABLE_ECHO_INPUT del modo de la consola
+const enableEchoInput = 0x0004
+
+var setConsoleMode = syscall.NewLazyDLL("kernel32.dll").NewProc("SetConsoleMode")
+
+// setEcho activa o desactiva el eco de la consola de la entrada estándar
+func setEcho(enabled bool) error {
+	handle := syscall.Handle(os.Stdin.Fd())
+	var mode uint32
+	if err := syscall.GetConsoleMode(handle, &mode); err != nil {
+		return err
+	}
+	if enabled {
+		mode |= enableEchoInput
+	} else {
+		mode &^= enableEchoInput
+	}
+	if ok, _, err := setConsoleMode.Call(uintptr(handle), uintptr(mode)); ok == 0 {
+		return err
+	}
+	return nil
+}
@@ -2,22 +2,136 @@ package commands
 
 import (
 	"fmt"
+	"polynode/i18n"
+	"regexp"
+	"strings"
 )
 
+// helpColumn es el ancho de la columna de nombres en la ayuda
+const helpColumn = 22
+
+// placeholderPattern reconoce las palabras de los parámetros de la ayuda ("<archivo|directorio>")
+var placeholderPattern = regexp.MustCompile(`\pL[\pL.]*`)
+
 func ShowHelp() {
-	fmt.Println("Uso: poly <comando> [parámetros]")
+	showCommandHelp(newRootCommand())
+}
+
+// showCommandHelp muestra la ayuda de un comando, generada a partir del árbol de comandos
+func showCommandHelp(command *Command) {
+	if command.parent == nil {
+		showRootHelp(command)
+		return
+	}
+
+	usage := i18n.T("help.usage", command.Path())
+	if len(command.Subcommands) > 0 && command.Run == nil {
+		usage += " " + i18n.T("help.subcommand_placeholder")
+	}
+	if command.Args != "" {
+		usage += " " + placeholders(command.Args)
+	}
+	if len(command.Flags) > 0 {
+		usage += " " + i18n.T("help.options_placeholder")
+	}
+	fmt.Println(usage)
+	fmt.Println("")
+	fmt.Println(command.Summary())
+	if description := command.Description(); description != "" {
+		fmt.Println(description)
+	}
+
+	if len(command.Subcommands) > 0 {
+		fmt.Println("")
+		fmt.Println(i18n.T("help.subcommands"))
+		for _, sub := range command.Subcommands {
+			printHelpLine(strings.TrimSpace(sub.Name+" "+placeholders(sub.Args)), sub.Summary())
+		}
+	}
+
+	if len(command.Flags) > 0 {
+		fmt.Println("")
+		fmt.Println(i18n.T("help.options"))
+		for _, flag := range command.Flags {
+			printHelpLine(flagLabel(flag), command.flagUsage(flag))
+		}
+	}
+
+	fmt.Println("")
+	if command.Structured {
+		fmt.Println(i18n.T("help.structured"))
+	}
+	fmt.Println(i18n.T("help.global_options_hint", strings.Join(flagNames(globalFlags), ", ")))
+}
+
+func showRootHelp(root *Command) {
+	fmt.Println(i18n.T("help.root_usage"))
 	fmt.Println("")
-	fmt.Println("Comandos:")
-	fmt.Println("---------")
-	fmt.Println(" install <version>      Instalar versión de node especificada en el repositorio local")
-	fmt.Println(" use <version>          Usar versión de node previamente instalada")
-	fmt.Println(" list                   Lista versiones de node instaladas en el repositorio local")
-	fmt.Println(" version                Muestra la versión de Node seleccionada")
-	fmt.Println(" uninstall <version>    Eliminar del repositorio local la versión de node especificada")
-	fmt.Println(" proxy <url>            Utilizar la url de proxy indicada para la descarga de versiones de Node")
-	fmt.Println(" check                  Revisar configuración de la instalación de polynode")
-	fmt.Println(" backup                 Realiza una copia de seguridad del repositorio y la versión actual")
-	fmt.Println(" shell                  Abrir shell con la versión actual de Node.js configurada en el PATH")
-	fmt.Println(" help                   Mostrar esta ayuda")
+	printHelpHeading(i18n.T("help.commands"))
+	for _, command := range root.Subcommands {
+		if command.Run != nil {
+			printHelpLine(strings.TrimSpace(command.Name+" "+placeholders(command.Args)), command.Summary())
+		}
+		for _, sub := range command.Subcommands {
+			printHelpLine(strings.TrimSpace(command.Name+" "+sub.Name+" "+placeholders(sub.Args)), sub.Summary())
+		}
+	}
+	fmt.Println("")
+	printHelpHeading(i18n.T("help.global_options"))
+	for _, flag := range globalFlags {
+		printHelpLine(flagLabel(flag), i18n.T("global.flag."+strings.TrimPrefix(flag.Name, "--")))
+	}
+	fmt.Println("")
+	printHelpHeading(i18n.T("help.exit_codes"))
+	printHelpLine(fmt.Sprint(ExitOK), i18n.T("help.exit.ok"))
+	printHelpLine(fmt.Sprint(ExitError), i18n.T("help.exit.error"))
+	printHelpLine(fmt.Sprint(ExitUsage), i18n.T("help.exit.usage"))
+	printHelpLine(fmt.Sprint(ExitNotFound), i18n.T("help.exit.not_found"))
+	printHelpLine(fmt.Sprint(ExitNetwork), i18n.T("help.exit.network"))
+	printHelpLine(fmt.Sprint(ExitIntegrity), i18n.T("help.exit.integrity"))
+	printHelpLine(fmt.Sprint(ExitEOL), i18n.T("help.exit.eol"))
+	printHelpLine(fmt.Sprint(ExitVulnerable), i18n.T("help.exit.vulnerable"))
+	fmt.Println("")
+	fmt.Println(i18n.T("help.command_help_hint"))
 	fmt.Println()
 }
+
+// printHelpHeading muestra un título de la ayuda subrayado
+func printHelpHeading(title string) {
+	fmt.Println(title + ":")
+	fmt.Println(strings.Repeat("-", len([]rune(title))+1))
+}
+
+// printHelpLine muestra un nombre y su descripción alineados en dos columnas
+func printHelpLine(name, description string) {
+	width := len([]rune(name))
+	if width >= helpColumn {
+		fmt.Printf(" %s  %s\n", name, description)
+		return
+	}
+	fmt.Printf(" %s%s %s\n", name, strings.Repeat(" ", helpColumn-width), description)
+}
+
+func flagLabel(flag Flag) string {
+	label := flag.Name
+	if flag.Short != "" {
+		label = flag.Short + ", " + label
+	}
+	if flag.Value != "" {
+		label += " " + placeholders(flag.Value)
+	}
+	return label
+}
+
+/*
+placeholders traduce las palabras de la descripción de los parámetros ("<archivo> [destino]")
+que tienen una clave "arg.<palabra>" en el catálogo; el resto ("json", "url") se deja igual.
+*/
+func placeholders(text string) string {
+	return placeholderPattern.ReplaceAllStringFunc(text, func(word string) string {
+		if i18n.Has("arg." + word) {
+			return i18n.T("arg." + word)
+		}
+		return word
+	})
+}
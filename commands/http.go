@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"polynode/i18n"
+	"polynode/pkg/manager"
+	"polynode/shared"
+	"strings"
+)
+
+/*
+buildHttpClient crea el cliente HTTP de las descargas e informa la configuración de red detectada.
+Los errores de NewHTTPClient se devuelven sin cambios para conservar su categoría.
+*/
+func buildHttpClient() (*http.Client, error) {
+	out := messageOutput()
+	client, err := manager.NewHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	// El aviso de insecure va siempre a stderr, también con --quiet o --output
+	if shared.GetConfigBool("insecure") {
+		border := strings.Repeat("*", 74)
+		fmt.Fprintln(os.Stderr, border)
+		for _, line := range strings.Split(i18n.T("http.insecure_warning"), "\n") {
+			fmt.Fprintf(os.Stderr, "* %-70s *\n", line)
+		}
+		fmt.Fprintln(os.Stderr, border)
+	}
+
+	if shared.GetConfig("proxy_pac") != "" {
+		fmt.Fprintln(out, i18n.T("http.pac_detected"))
+	} else if shared.GetConfig("http_proxy") != "" || shared.GetConfig("https_proxy") != "" {
+		fmt.Fprintln(out, i18n.T("http.proxy_detected"))
+	}
+
+	return client, nil
+}
@@ -0,0 +1,189 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"polynode/i18n"
+	"polynode/pkg/manager"
+	"polynode/shared"
+	"strings"
+	"text/tabwriter"
+)
+
+/*
+infoOutput es la salida estructurada de "poly info". Los datos del índice son null si la versión
+no está en index.json (por ejemplo, si se instaló sin conexión), y Path y Size si no está instalada.
+*/
+type infoOutput struct {
+	Version        string   `json:"version"`
+	Date           *string  `json:"date"`
+	LTS            *string  `json:"lts"`
+	Security       bool     `json:"security"`
+	Npm            *string  `json:"npm"`
+	V8             *string  `json:"v8"`
+	Uv             *string  `json:"uv"`
+	Zlib           *string  `json:"zlib"`
+	OpenSSL        *string  `json:"openssl"`
+	Modules        *string  `json:"modules"`
+	Files          []string `json:"files"`
+	Platform       string   `json:"platform"`
+	Available      bool     `json:"available"`
+	Installed      bool     `json:"installed"`
+	Current        bool     `json:"current"`
+	Path           *string  `json:"path"`
+	Size           *int64   `json:"size"`
+	Aliases        []string `json:"aliases"`
+	SameABI        *bool    `json:"sameAbiAsCurrent"`
+	CurrentVersion *string  `json:"currentVersion"`
+}
+
+/*
+ExecuteInfo muestra los datos de una versión publicada: fecha, línea LTS, versiones de los
+componentes incluidos (npm, V8, libuv, zlib, OpenSSL y la ABI de los módulos nativos), las
+plataformas publicadas y, si está instalada, su ruta y tamaño. También indica si la ABI coincide
+con la de la versión actual, para comprobar la compatibilidad de los módulos nativos antes de cambiar.
+*/
+func ExecuteInfo(args []string) error {
+	if len(args) != 1 {
+		return usageError("cli.command_usage", placeholders("info <versión>"))
+	}
+
+	m := newManager(nil)
+	if !StructuredOutput() {
+		m = newManager(newConsoleReporter(false))
+	}
+	version, err := m.Resolve(args[0])
+	if err != nil {
+		return err
+	}
+	index, err := m.Index()
+	if err != nil {
+		return err
+	}
+
+	entries := map[string]manager.IndexEntry{}
+	for _, entry := range index {
+		entries[shared.NormalizeVersion(entry.Version)] = entry
+	}
+	entry, published := entries[version]
+	if !published && !isInstalled(version) {
+		return withCode(ErrorCodeNotFound, i18n.Errorf("info.not_found", version))
+	}
+
+	output := infoOutput{
+		Version:   version,
+		Files:     []string{},
+		Platform:  shared.GetPlatform(),
+		Installed: isInstalled(version),
+		Aliases:   []string{},
+	}
+	if published {
+		output.Date = optionalString(entry.Date)
+		output.LTS = optionalString(entry.LTSName())
+		output.Security = entry.Security
+		output.Npm = optionalString(entry.Npm)
+		output.V8 = optionalString(entry.V8)
+		output.Uv = optionalString(entry.Uv)
+		output.Zlib = optionalString(entry.Zlib)
+		output.OpenSSL = optionalString(entry.OpenSSL)
+		output.Modules = optionalString(entry.Modules)
+		output.Files = append(output.Files, entry.Files...)
+		output.Available = entry.HasPlatform(output.Platform)
+	}
+	if output.Installed {
+		path := shared.GetVersionPath(version)
+		output.Path = &path
+		output.Available = true
+		if size, err := manager.DirSize(path); err == nil {
+			output.Size = &size
+		}
+	}
+	output.Aliases = append(output.Aliases, aliasesByVersion()[version]...)
+
+	if currentVersion, _ := m.Current(); currentVersion != "" {
+		output.Current = currentVersion == version
+		output.CurrentVersion = &currentVersion
+		if current, ok := entries[currentVersion]; ok && output.Modules != nil && current.Modules != "" {
+			sameABI := current.Modules == *output.Modules
+			output.SameABI = &sameABI
+		}
+	}
+
+	if StructuredOutput() {
+		return writeOutput(output)
+	}
+	printVersionInfo(output)
+	return nil
+}
+
+// printVersionInfo muestra los datos de una versión en formato de texto, con las etiquetas alineadas
+func printVersionInfo(info infoOutput) {
+	title := "Node v" + info.Version
+	if info.LTS != nil {
+		title += fmt.Sprintf(" (LTS: %s)", *info.LTS)
+	}
+	fmt.Println(title)
+
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	row := func(key string, value string) {
+		fmt.Fprintf(table, " %s:\t%s\n", i18n.T(key), value)
+	}
+	optional := func(value *string) string {
+		if value == nil {
+			return "-"
+		}
+		return *value
+	}
+
+	row("info.date", optional(info.Date))
+	row("info.security", yesNo(info.Security))
+	row("info.npm", optional(info.Npm))
+	row("info.v8", optional(info.V8))
+	row("info.uv", optional(info.Uv))
+	row("info.zlib", optional(info.Zlib))
+	row("info.openssl", optional(info.OpenSSL))
+	row("info.modules", optional(info.Modules))
+	if len(info.Files) > 0 {
+		row("info.files", strings.Join(info.Files, ", "))
+	}
+	fmt.Fprintf(table, " %s:\t%s\n", i18n.T("info.available", info.Platform), yesNo(info.Available))
+
+	installed := yesNo(info.Installed)
+	if info.Current {
+		installed += i18n.T("info.current_marker")
+	}
+	row("info.installed", installed)
+	if info.Path != nil {
+		row("info.path", *info.Path)
+	}
+	if info.Size != nil {
+		row("info.size", shared.FormatSize(*info.Size))
+	}
+	if len(info.Aliases) > 0 {
+		row("info.aliases", strings.Join(info.Aliases, ", "))
+	}
+	if info.SameABI != nil && !info.Current {
+		abi := i18n.T("info.abi_same", *info.CurrentVersion)
+		if !*info.SameABI {
+			abi = i18n.T("info.abi_different", *info.CurrentVersion)
+		}
+		row("info.abi", abi)
+	}
+	table.Flush()
+}
+
+// optionalString devuelve nil para una cadena vacía, para mostrarla como null en la salida estructurada
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// yesNo muestra un valor booleano en el idioma seleccionado
+func yesNo(value bool) string {
+	if value {
+		return i18n.T("info.yes")
+	}
+	return i18n.T("info.no")
+}
@@ -0,0 +1,309 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"polynode/i18n"
+	"polynode/pkg/manager"
+	"polynode/shared"
+	"sort"
+	"time"
+)
+
+const lockFileVersion = 1
+
+/*
+lockFile describe un entorno de polynode sin incluir los binarios de Node: las versiones
+instaladas (con el SHA-256 de su archivo), la versión actual, la configuración (sin las
+credenciales de las URLs), los alias y los paquetes globales de npm de cada versión. Con "poly import" se reproduce el mismo entorno en otro equipo.
+*/
+type lockFile struct {
+	LockfileVersion int               `json:"lockfileVersion"`
+	Created         time.Time         `json:"created"`
+	Platform        string            `json:"platform"`
+	Arch            string            `json:"arch"`
+	Current         string            `json:"current,omitempty"`
+	Config          map[string]string `json:"config,omitempty"`
+	Aliases         map[string]string `json:"aliases,omitempty"`
+	Versions        []lockVersion     `json:"versions"`
+}
+
+type lockVersion struct {
+	Version string       `json:"version"`
+	Archive string       `json:"archive"`
+	SHA256  string       `json:"sha256,omitempty"`
+	Globals []npmPackage `json:"globals,omitempty"`
+}
+
+// ExecuteExport escribe el manifiesto del entorno en la salida estándar o en el archivo indicado
+func ExecuteExport(args []string) error {
+	if len(args) > 1 {
+		return usageError("cli.command_usage", placeholders("export [archivo]"))
+	}
+
+	lock, err := buildLockFile()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(lock, "", "    ")
+	if err != nil {
+		return i18n.Errorf("lock.encode_error", err)
+	}
+	data = append(data, '\n')
+
+	if len(args) == 0 {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(args[0], data, 0644); err != nil {
+		return i18n.Errorf("lock.save_error", args[0], err)
+	}
+	fmt.Print(i18n.N("lock.exported", len(lock.Versions), args[0], len(lock.Versions)))
+	return nil
+}
+
+func buildLockFile() (*lockFile, error) {
+	versions, err := listInstalledVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	lock := &lockFile{
+		LockfileVersion: lockFileVersion,
+		Created:         time.Now().UTC(),
+		Platform:        shared.GetPlatform(),
+		Arch:            shared.GetConfig("arch"),
+		Current:         shared.GetCurrentVersion(),
+		Config:          redactConfig(shared.GetUserConfigSettings()),
+	}
+
+	aliases, err := manager.Aliases()
+	if err != nil {
+		return nil, err
+	}
+	for _, alias := range aliases {
+		if lock.Aliases == nil {
+			lock.Aliases = map[string]string{}
+		}
+		lock.Aliases[alias.Name] = alias.Target
+	}
+
+	// El manager sólo accede a la red si el SHASUMS256.txt de alguna versión no está en la caché;
+	// se usa el cliente sin mensajes para no mezclarlos con el manifiesto en la salida estándar
+	m := manager.New(manager.Options{})
+	for _, version := range versions {
+		entry := lockVersion{Version: version, Archive: shared.GetArchiveName(version)}
+
+		if checksums, err := m.Checksums(version); err == nil {
+			entry.SHA256 = checksums[entry.Archive]
+		}
+		if entry.SHA256 == "" {
+			fmt.Fprint(os.Stderr, i18n.T("lock.no_checksum", entry.Archive))
+		}
+
+		if entry.Globals, err = listGlobalPackages(versionInstallPath(version)); err != nil {
+			return nil, err
+		}
+		lock.Versions = append(lock.Versions, entry)
+	}
+
+	return lock, nil
+}
+
+// redactConfig quita el usuario y la contraseña de las URLs de la configuración exportada
+func redactConfig(settings map[string]string) map[string]string {
+	for name, value := range settings {
+		u, err := url.Parse(value)
+		if err != nil || u.Host == "" || u.User == nil {
+			continue
+		}
+		u.User = nil
+		settings[name] = u.String()
+		fmt.Fprint(os.Stderr, i18n.T("lock.credentials_removed", name))
+	}
+	return settings
+}
+
+/*
+ExecuteImport reproduce el entorno descrito en un manifiesto: instala las versiones que falten
+verificando su SHA-256, instala los paquetes globales de npm y selecciona la versión actual.
+La configuración sólo se aplica con --with-config, porque un manifiesto de un tercero podría
+cambiar el mirror o el proxy y desactivar la verificación de los certificados.
+*/
+func ExecuteImport(args []string) error {
+	source := ""
+	withConfig := false
+	withGlobals := true
+	for _, arg := range args {
+		switch arg {
+		case "--with-config":
+			withConfig = true
+		case "--no-globals":
+			withGlobals = false
+		default:
+			source = arg
+		}
+	}
+	if source == "" {
+		return usageError("cli.command_usage", placeholders("import <archivo> [--with-config] [--no-globals]"))
+	}
+
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return i18n.Errorf("lock.read_error", source, err)
+	}
+	var lock lockFile
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return i18n.Errorf("lock.invalid", source, err)
+	}
+	if lock.LockfileVersion != lockFileVersion {
+		return i18n.Errorf("lock.unsupported_version", lock.LockfileVersion)
+	}
+
+	// La configuración se aplica primero, para que el mirror y el proxy se usen en las descargas
+	if withConfig {
+		importConfig(lock.Config)
+	} else if len(lock.Config) > 0 {
+		fmt.Print(i18n.T("lock.config_skipped"))
+	}
+
+	verifyChecksums := lock.Platform == shared.GetPlatform()
+	if !verifyChecksums {
+		fmt.Print(i18n.T("lock.other_platform", lock.Platform, shared.GetPlatform()))
+	}
+
+	if err := installLockedVersions(lock.Versions, verifyChecksums); err != nil {
+		return err
+	}
+
+	if withGlobals {
+		for _, entry := range lock.Versions {
+			if err := installMissingGlobals(entry); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := importAliases(lock.Aliases); err != nil {
+		return err
+	}
+
+	if lock.Current != "" && lock.Current != shared.GetCurrentVersion() {
+		if _, err := UseNodeVersion(lock.Current); err != nil {
+			return err
+		}
+		fmt.Print(i18n.T("lock.current", lock.Current))
+	}
+
+	fmt.Print(i18n.T("lock.imported", source))
+	return nil
+}
+
+/*
+importConfig aplica la configuración del manifiesto. Las opciones de red y de seguridad se
+muestran antes y sólo se aplican si el usuario lo confirma.
+*/
+func importConfig(config map[string]string) {
+	names := make([]string, 0, len(config))
+	var sensitive []string
+	for name := range config {
+		names = append(names, name)
+		if key, ok := shared.LookupConfigKey(name); ok && key.Sensitive {
+			sensitive = append(sensitive, name)
+		}
+	}
+	sort.Strings(names)
+	sort.Strings(sensitive)
+
+	applySensitive := true
+	if len(sensitive) > 0 {
+		fmt.Print(i18n.T("lock.sensitive_config"))
+		for _, name := range sensitive {
+			fmt.Printf("  %s = %s\n", name, config[name])
+		}
+		applySensitive = confirm(i18n.T("lock.sensitive_confirm"))
+		if !applySensitive {
+			fmt.Print(i18n.T("lock.sensitive_skipped"))
+		}
+	}
+
+	for _, name := range names {
+		if key, ok := shared.LookupConfigKey(name); ok && key.Sensitive && !applySensitive {
+			continue
+		}
+		if err := shared.SetUserConfigValue(name, config[name]); err != nil {
+			fmt.Print(i18n.T("lock.config_ignored", name, err))
+			continue
+		}
+		fmt.Print(i18n.T("lock.config_set", name, config[name]))
+	}
+}
+
+// importAliases crea los alias del manifiesto; los de líneas de versiones se resuelven con el índice del mirror
+func importAliases(aliases map[string]string) error {
+	var names []string
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	m := newManager(nil)
+	for _, name := range names {
+		alias, err := m.SetAlias(name, aliases[name])
+		if err != nil {
+			return err
+		}
+		fmt.Print(i18n.T("lock.alias", alias.Name, alias.Target, alias.Version))
+	}
+	return nil
+}
+
+// installLockedVersions instala las versiones del manifiesto que no estén instaladas
+func installLockedVersions(entries []lockVersion, verifyChecksums bool) error {
+	var missing []string
+	for _, entry := range entries {
+		if _, err := os.Stat(shared.GetVersionPath(entry.Version)); err == nil {
+			fmt.Print(i18n.T("lock.already_installed", entry.Version))
+			continue
+		}
+		missing = append(missing, entry.Version)
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	m := newManager(nil)
+	if _, err := m.Client(); err != nil {
+		return err
+	}
+
+	// El SHA-256 publicado por el mirror debe coincidir con el del manifiesto
+	if verifyChecksums {
+		for _, entry := range entries {
+			if entry.SHA256 == "" || !containsString(missing, entry.Version) {
+				continue
+			}
+			checksums, err := m.Checksums(entry.Version)
+			if err != nil {
+				return err
+			}
+			if checksums[entry.Archive] != entry.SHA256 {
+				return withCode(ErrorCodeIntegrity, i18n.Errorf("lock.checksum_mismatch", entry.Archive, checksums[entry.Archive], entry.SHA256))
+			}
+		}
+	}
+
+	return InstallVersions(missing, installOptions{})
+}
+
+// installMissingGlobals instala los paquetes globales del manifiesto que falten en una versión
+func installMissingGlobals(entry lockVersion) error {
+	if len(entry.Globals) == 0 {
+		return nil
+	}
+
+	return installMissingPackages(entry.Version, entry.Globals)
+}
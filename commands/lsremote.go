@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"fmt"
+	"polynode/i18n"
+	"polynode/pkg/manager"
+	"polynode/shared"
+	"sort"
+	"strings"
+)
+
+// lsRemoteOutput es la salida estructurada de "poly ls-remote"; LTS es null si la versión no es LTS
+type lsRemoteOutput struct {
+	Versions []remoteVersionOutput `json:"versions"`
+}
+
+type remoteVersionOutput struct {
+	Version   string  `json:"version"`
+	LTS       *string `json:"lts"`
+	Installed bool    `json:"installed"`
+	Current   bool    `json:"current"`
+}
+
+/*
+ExecuteLsRemote lista las versiones publicadas en el mirror, de la más antigua a la más nueva.
+Se puede filtrar por una línea de versiones ("20", "20.11") y mostrar sólo las versiones LTS.
+*/
+func ExecuteLsRemote(args []string) error {
+	filter := ""
+	ltsOnly := false
+	for _, arg := range args {
+		switch {
+		case arg == "--lts":
+			ltsOnly = true
+		case filter == "" && !strings.HasPrefix(arg, "-"):
+			filter = shared.NormalizeVersion(arg)
+		default:
+			return usageError("cli.command_usage", placeholders("ls-remote [versión] [--lts]"))
+		}
+	}
+
+	m := newManager(nil)
+	if !StructuredOutput() {
+		m = newManager(newConsoleReporter(false))
+	}
+	index, err := m.Index()
+	if err != nil {
+		return err
+	}
+
+	installed := map[string]bool{}
+	if versions, err := manager.ListInstalledVersions(); err == nil {
+		for _, version := range versions {
+			installed[version] = true
+		}
+	}
+	currentVersion, _ := m.Current()
+
+	var entries []manager.IndexEntry
+	for _, entry := range index {
+		version := shared.NormalizeVersion(entry.Version)
+		if _, err := manager.ParseVersion(version); err != nil {
+			continue
+		}
+		if filter != "" && version != filter && !strings.HasPrefix(version, filter+".") {
+			continue
+		}
+		if ltsOnly && entry.LTSName() == "" {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		v1, _ := manager.ParseVersion(shared.NormalizeVersion(entries[i].Version))
+		v2, _ := manager.ParseVersion(shared.NormalizeVersion(entries[j].Version))
+		return manager.CompareVersions(v1, v2) < 0
+	})
+
+	if StructuredOutput() {
+		output := lsRemoteOutput{Versions: []remoteVersionOutput{}}
+		for _, entry := range entries {
+			version := shared.NormalizeVersion(entry.Version)
+			remote := remoteVersionOutput{Version: version, Installed: installed[version], Current: version == currentVersion}
+			if lts := entry.LTSName(); lts != "" {
+				remote.LTS = &lts
+			}
+			output.Versions = append(output.Versions, remote)
+		}
+		return writeOutput(output)
+	}
+
+	if len(entries) == 0 {
+		fmt.Println(i18n.T("lsremote.empty"))
+		return nil
+	}
+
+	fmt.Println(i18n.T("lsremote.title"))
+	for _, entry := range entries {
+		version := shared.NormalizeVersion(entry.Version)
+		line := " - " + version
+		if lts := entry.LTSName(); lts != "" {
+			line += fmt.Sprintf(" (LTS: %s)", lts)
+		}
+		if version == currentVersion {
+			line += i18n.T("lsremote.current_marker")
+		} else if installed[version] {
+			line += i18n.T("lsremote.installed_marker")
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
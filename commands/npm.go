@@ -0,0 +1,275 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"polynode/i18n"
+	"polynode/pkg/manager"
+	"polynode/shared"
+	"sort"
+	"strings"
+)
+
+// bundledPackages son los paquetes globales que se instalan junto con Node
+var bundledPackages = map[string]bool{"npm": true, "corepack": true}
+
+// npmPackage es un paquete global de npm instalado en una versión de Node
+type npmPackage struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// parseNpmPackage interpreta un paquete con el formato de npm install ("typescript", "typescript@5" o "@nestjs/cli@10")
+func parseNpmPackage(spec string) npmPackage {
+	if i := strings.LastIndex(spec, "@"); i > 0 {
+		return npmPackage{Name: spec[:i], Version: spec[i+1:]}
+	}
+	return npmPackage{Name: spec}
+}
+
+func (p npmPackage) Spec() string {
+	if p.Version == "" {
+		return p.Name
+	}
+	return p.Name + "@" + p.Version
+}
+
+/*
+versionInstallPath devuelve el directorio donde está instalada una versión. Si la versión es
+la actual y current es una copia, los paquetes globales instalados desde la selección de la
+versión están en current y no en el repositorio.
+*/
+func versionInstallPath(version string) string {
+	if !manager.IsCurrentLinked() && shared.GetCurrentVersion() == version {
+		return shared.GetCurrentVersionPath()
+	}
+	return shared.GetVersionPath(version)
+}
+
+// listGlobalPackages lee los paquetes globales de npm de un directorio de versión, sin ejecutar npm
+func listGlobalPackages(versionPath string) ([]npmPackage, error) {
+	modulesPath := shared.GetGlobalModulesPath(versionPath)
+	entries, err := os.ReadDir(modulesPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, i18n.Errorf("npm.read_globals_error", versionPath, err)
+	}
+
+	var dirs []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		// Los paquetes con scope están un nivel más abajo (@scope/paquete)
+		if strings.HasPrefix(name, "@") {
+			scoped, _ := os.ReadDir(filepath.Join(modulesPath, name))
+			for _, entry := range scoped {
+				dirs = append(dirs, name+"/"+entry.Name())
+			}
+			continue
+		}
+		dirs = append(dirs, name)
+	}
+
+	var packages []npmPackage
+	for _, dir := range dirs {
+		if bundledPackages[dir] {
+			continue
+		}
+		body, err := os.ReadFile(filepath.Join(modulesPath, filepath.FromSlash(dir), "package.json"))
+		if err != nil {
+			continue
+		}
+		var pkg npmPackage
+		if err := json.Unmarshal(body, &pkg); err != nil || pkg.Name == "" {
+			continue
+		}
+		packages = append(packages, pkg)
+	}
+
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Name < packages[j].Name
+	})
+	return packages, nil
+}
+
+/*
+installGlobalPackages instala paquetes globales de npm en un directorio de versión, ejecutando
+el npm incluido en esa versión con su propio node, de modo que no dependa del PATH.
+*/
+func installGlobalPackages(versionPath string, packages []npmPackage) error {
+	if len(packages) == 0 {
+		return nil
+	}
+
+	npmCli := shared.GetNpmCli(versionPath)
+	if _, err := os.Stat(npmCli); err != nil {
+		return i18n.Errorf("npm.not_found", versionPath)
+	}
+
+	args := []string{npmCli, "install", "--global"}
+	for _, pkg := range packages {
+		args = append(args, pkg.Spec())
+	}
+
+	cmd := exec.Command(shared.GetNodeExecutable(versionPath), args...)
+	cmd.Env = append(os.Environ(), "npm_config_prefix="+versionPath)
+	cmd.Stdout = messageOutput()
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return i18n.Errorf("npm.install_globals_error", err)
+	}
+	return nil
+}
+
+/*
+missingGlobalPackages devuelve los paquetes que no están instalados en un directorio de versión.
+Un paquete sin versión se considera instalado si hay cualquier versión del mismo nombre.
+*/
+func missingGlobalPackages(versionPath string, packages []npmPackage) ([]npmPackage, error) {
+	installed, err := listGlobalPackages(versionPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []npmPackage
+	for _, pkg := range packages {
+		found := false
+		for _, current := range installed {
+			if current.Name == pkg.Name && (pkg.Version == "" || current.Version == pkg.Version) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, pkg)
+		}
+	}
+	return missing, nil
+}
+
+// defaultPackagesPath devuelve la ruta del archivo de paquetes globales que se instalan en cada versión nueva
+func defaultPackagesPath() string {
+	if path := shared.GetConfig("default_packages"); path != "" {
+		return path
+	}
+	return filepath.Join(shared.GetInstallPath(), "default-packages")
+}
+
+/*
+readDefaultPackages lee el archivo de paquetes por defecto: un paquete por línea, con el formato
+de npm install ("typescript" o "typescript@5"). Se ignoran las líneas vacías y los comentarios (#).
+Si el archivo no existe, no hay paquetes por defecto.
+*/
+func readDefaultPackages() ([]npmPackage, error) {
+	body, err := os.ReadFile(defaultPackagesPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, i18n.Errorf("npm.default_packages_error", defaultPackagesPath(), err)
+	}
+
+	var packages []npmPackage
+	for _, line := range strings.Split(string(body), "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		if line = strings.TrimSpace(line); line != "" {
+			packages = append(packages, parseNpmPackage(line))
+		}
+	}
+	return packages, nil
+}
+
+// globalPackagesFrom devuelve los paquetes globales de una versión instalada; sin exact, sin sus versiones
+func globalPackagesFrom(version string, exact bool) ([]npmPackage, error) {
+	packages, err := listGlobalPackages(versionInstallPath(version))
+	if err != nil || exact {
+		return packages, err
+	}
+	for i := range packages {
+		packages[i].Version = ""
+	}
+	return packages, nil
+}
+
+/*
+setupGlobalPackages instala en una versión recién instalada los paquetes por defecto y, si se
+indicó --reinstall-packages-from, los paquetes globales de otra versión.
+*/
+func setupGlobalPackages(version string, options installOptions) error {
+	packages, err := readDefaultPackages()
+	if err != nil {
+		return err
+	}
+	if options.PackagesFrom != "" && options.PackagesFrom != version {
+		migrated, err := globalPackagesFrom(options.PackagesFrom, options.ExactPackages)
+		if err != nil {
+			return err
+		}
+		packages = append(packages, migrated...)
+	}
+	return installMissingPackages(version, packages)
+}
+
+// installMissingPackages instala en una versión los paquetes globales que le falten
+func installMissingPackages(version string, packages []npmPackage) error {
+	versionPath := versionInstallPath(version)
+	missing, err := missingGlobalPackages(versionPath, packages)
+	if err != nil || len(missing) == 0 {
+		return err
+	}
+
+	fmt.Fprint(messageOutput(), i18n.N("npm.installing_globals", len(missing), len(missing), version))
+	return installGlobalPackages(versionPath, missing)
+}
+
+// ExecuteMigrateGlobals instala en una versión los paquetes globales de npm de otra versión
+func ExecuteMigrateGlobals(args []string) error {
+	exact := false
+	var versions []string
+	for _, arg := range args {
+		if arg == "--exact" {
+			exact = true
+			continue
+		}
+		versions = append(versions, arg)
+	}
+	if len(versions) != 2 {
+		return usageError("cli.command_usage", placeholders("migrate-globals <origen> <destino> [--exact]"))
+	}
+
+	from, err := manager.ResolveInstalled(versions[0])
+	if err != nil {
+		return err
+	}
+	to, err := manager.ResolveInstalled(versions[1])
+	if err != nil {
+		return err
+	}
+
+	packages, err := globalPackagesFrom(from, exact)
+	if err != nil {
+		return err
+	}
+	missing, err := missingGlobalPackages(versionInstallPath(to), packages)
+	if err != nil {
+		return err
+	}
+	if len(missing) == 0 {
+		printInfo("npm.nothing_to_migrate", from, to)
+		return nil
+	}
+
+	fmt.Fprint(messageOutput(), i18n.N("npm.migrating", len(missing), len(missing), from, to))
+	if err := installGlobalPackages(versionInstallPath(to), missing); err != nil {
+		return err
+	}
+	printInfo("npm.migrated", to)
+	return nil
+}
@@ -0,0 +1,227 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"polynode/i18n"
+	"polynode/pkg/manager"
+	"polynode/shared"
+	"regexp"
+	"strings"
+)
+
+var (
+	versionDirPattern   = regexp.MustCompile(`^node-v(\d+\.\d+\.\d+)-([a-z]+-[a-z0-9]+)$`)
+	versionHeaderDefine = regexp.MustCompile(`#define\s+NODE_(MAJOR|MINOR|PATCH)_VERSION\s+(\d+)`)
+)
+
+// ExecuteInstall interpreta los argumentos de "poly install"
+func ExecuteInstall(args []string) error {
+	from := ""
+	shasums := ""
+	var options installOptions
+	var versions []string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--from", "--shasums", "--reinstall-packages-from":
+			if i+1 >= len(args) {
+				return usageError("cli.missing_flag_value", args[i])
+			}
+			switch args[i] {
+			case "--from":
+				from = args[i+1]
+			case "--shasums":
+				shasums = args[i+1]
+			default:
+				options.PackagesFrom = args[i+1]
+			}
+			i++
+		case "--exact":
+			options.ExactPackages = true
+		default:
+			versions = append(versions, args[i])
+		}
+	}
+
+	if from != "" {
+		if len(versions) > 0 {
+			return usageError("offline.versions_with_from")
+		}
+		if StructuredOutput() {
+			return UnsupportedOutputError("install --from")
+		}
+		// Instalar paquetes de npm requiere conexión
+		if options.PackagesFrom != "" {
+			return usageError("offline.packages_with_from")
+		}
+		return InstallFromPath(from, shasums)
+	}
+
+	if len(versions) == 0 {
+		return usageError("offline.usage", placeholders("install --from <archivo|directorio> [--shasums <archivo>]"))
+	}
+
+	// La versión de origen de los paquetes se resuelve antes de descargar nada
+	if options.PackagesFrom != "" {
+		version, err := manager.ResolveInstalled(options.PackagesFrom)
+		if err != nil {
+			return err
+		}
+		options.PackagesFrom = version
+	}
+	return InstallVersions(versions, options)
+}
+
+/*
+InstallFromPath instala una versión a partir de un archivo local (.zip, .tar.gz o .tar.xz)
+o de un directorio ya extraído, sin acceder a la red. La versión y la plataforma se detectan
+a partir del contenido, y el archivo se puede validar contra un SHASUMS256.txt local.
+*/
+func InstallFromPath(source, shasumsFile string) error {
+	info, err := os.Stat(source)
+	if err != nil {
+		return i18n.Errorf("offline.access_error", source, err)
+	}
+
+	if err := os.MkdirAll(shared.GetRepoPath(), os.ModePerm); err != nil {
+		return i18n.Errorf("offline.install_dir_error", err)
+	}
+
+	if info.IsDir() {
+		if shasumsFile != "" {
+			fmt.Println(i18n.T("offline.shasums_ignored"))
+		}
+		version, err := detectVersionDir(source)
+		if err != nil {
+			return err
+		}
+		fmt.Print(i18n.T("offline.copying", version, source))
+		if err := manager.CopyDir(source, shared.GetVersionPath(version)); err != nil {
+			os.RemoveAll(shared.GetVersionPath(version))
+			return i18n.Errorf("offline.copy_error", err)
+		}
+		fmt.Print(i18n.T("offline.installed", version, shared.GetInstallPath()))
+		return nil
+	}
+
+	sha := ""
+	if shasumsFile != "" {
+		if sha, err = verifyLocalChecksum(source, shasumsFile); err != nil {
+			return err
+		}
+		fmt.Print(i18n.T("offline.checksum_ok", filepath.Base(source), filepath.Base(shasumsFile)))
+	}
+
+	// Extraer en un directorio temporal dentro del repositorio, para poder renombrarlo al final
+	tmpDir, err := os.MkdirTemp(shared.GetRepoPath(), ".offline-")
+	if err != nil {
+		return i18n.Errorf("offline.temp_error", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	fmt.Println(i18n.T("offline.extracting"))
+	if err := manager.ExtractArchive(source, tmpDir); err != nil {
+		return i18n.Errorf("offline.extract_error", err)
+	}
+
+	root, err := archiveRoot(tmpDir)
+	if err != nil {
+		return err
+	}
+	version, err := detectVersionDir(root)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Rename(root, shared.GetVersionPath(version)); err != nil {
+		return i18n.Errorf("offline.move_error", err)
+	}
+
+	// Guardar el archivo en la caché de descargas, como si se hubiera descargado
+	if filepath.Base(source) == shared.GetArchiveName(version) {
+		if file, err := os.Open(source); err == nil {
+			manager.StoreInCache(file, filepath.Base(source), sha)
+			file.Close()
+		}
+	}
+
+	fmt.Print(i18n.T("offline.installed", version, shared.GetInstallPath()))
+	return nil
+}
+
+/*
+detectVersionDir obtiene la versión de un directorio de distribución de Node, a partir de
+su nombre (node-v<versión>-<plataforma>) o del archivo include/node/node_version.h, y verifica
+que la plataforma coincida con la configurada y que la versión no esté ya instalada.
+*/
+func detectVersionDir(dir string) (string, error) {
+	version := ""
+	platform := ""
+
+	if match := versionDirPattern.FindStringSubmatch(filepath.Base(dir)); match != nil {
+		version, platform = match[1], match[2]
+	} else {
+		header, err := os.ReadFile(filepath.Join(dir, "include", "node", "node_version.h"))
+		if err != nil {
+			return "", i18n.Errorf("offline.version_not_detected", dir)
+		}
+		parts := map[string]string{}
+		for _, match := range versionHeaderDefine.FindAllStringSubmatch(string(header), -1) {
+			parts[match[1]] = match[2]
+		}
+		if len(parts) != 3 {
+			return "", i18n.Errorf("offline.version_not_detected", dir)
+		}
+		version = fmt.Sprintf("%s.%s.%s", parts["MAJOR"], parts["MINOR"], parts["PATCH"])
+	}
+
+	if _, err := os.Stat(shared.GetNodeExecutable(dir)); err != nil {
+		return "", i18n.Errorf("offline.node_not_found", shared.GetPlatform(), dir)
+	}
+	if platform != "" && platform != shared.GetPlatform() {
+		return "", i18n.Errorf("offline.platform_mismatch", platform, shared.GetPlatform())
+	}
+
+	if _, err := os.Stat(shared.GetVersionPath(version)); err == nil {
+		return "", i18n.Errorf("offline.already_installed", version)
+	}
+
+	return version, nil
+}
+
+// archiveRoot devuelve el directorio raíz del contenido extraído
+func archiveRoot(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 1 && entries[0].IsDir() {
+		return filepath.Join(dir, entries[0].Name()), nil
+	}
+	return dir, nil
+}
+
+// verifyLocalChecksum valida un archivo contra un SHASUMS256.txt local y devuelve su SHA-256
+func verifyLocalChecksum(archive, shasumsFile string) (string, error) {
+	file, err := os.Open(shasumsFile)
+	if err != nil {
+		return "", i18n.Errorf("offline.shasums_open_error", shasumsFile, err)
+	}
+	defer file.Close()
+
+	expected, ok := manager.ParseChecksums(file)[filepath.Base(archive)]
+	if !ok {
+		return "", i18n.Errorf("offline.not_in_shasums", filepath.Base(archive), shasumsFile)
+	}
+
+	sha, err := manager.FileSHA256(archive)
+	if err != nil {
+		return "", i18n.Errorf("offline.checksum_error", archive, err)
+	}
+	if !strings.EqualFold(sha, expected) {
+		return "", withCode(ErrorCodeIntegrity, i18n.Errorf("offline.checksum_mismatch", filepath.Base(archive), shasumsFile, expected, sha))
+	}
+	return sha, nil
+}
@@ -0,0 +1,181 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"polynode/i18n"
+	"polynode/pkg/manager"
+	"polynode/shared"
+	"text/tabwriter"
+	"time"
+)
+
+// outdatedOutput es la salida estructurada de "poly outdated"; Codename y EOL son null si no se conocen
+type outdatedOutput struct {
+	Versions []outdatedVersionOutput `json:"versions"`
+}
+
+type outdatedVersionOutput struct {
+	Version  string  `json:"version"`
+	Latest   string  `json:"latest"`
+	Outdated bool    `json:"outdated"`
+	Line     string  `json:"line"`
+	Codename *string `json:"codename"`
+	Status   string  `json:"status"`
+	EOL      *string `json:"eol"`
+	Current  bool    `json:"current"`
+}
+
+/*
+ExecuteOutdated compara las versiones instaladas con index.json y con el calendario de
+publicaciones de Node (schedule.json): muestra la revisión más nueva de la línea de cada versión,
+si la línea es LTS, está en mantenimiento o llegó al fin de vida, y la fecha de fin de soporte.
+Con --fail-on-eol termina con un código de salida distinto de cero si alguna versión llegó al
+fin de vida, para usarlo en integración continua.
+*/
+func ExecuteOutdated(args []string) error {
+	failOnEOL := false
+	for _, arg := range args {
+		if arg != "--fail-on-eol" {
+			return usageError("cli.command_usage", "outdated [--fail-on-eol]")
+		}
+		failOnEOL = true
+	}
+
+	versions, err := listInstalledVersions()
+	if err != nil {
+		return err
+	}
+
+	m := newManager(nil)
+	if !StructuredOutput() {
+		m = newManager(newConsoleReporter(false))
+	}
+	index, err := m.Index()
+	if err != nil {
+		return err
+	}
+	latest := latestByLine(index)
+
+	// Sin el calendario se informa la última revisión, pero no el estado de las líneas
+	schedule, err := m.Schedule()
+	if err != nil {
+		if failOnEOL {
+			return err
+		}
+		fmt.Fprint(os.Stderr, i18n.T("outdated.no_schedule", err))
+	}
+	currentVersion, _ := m.Current()
+
+	now := time.Now()
+	output := outdatedOutput{Versions: []outdatedVersionOutput{}}
+	eol := 0
+	for _, version := range versions {
+		parsed, err := manager.ParseVersion(version)
+		if err != nil {
+			continue
+		}
+		line := manager.ReleaseLineName(parsed)
+		item := outdatedVersionOutput{
+			Version: version,
+			Latest:  version,
+			Line:    line,
+			Status:  manager.StatusUnknown,
+			Current: version == currentVersion,
+		}
+		if newest, ok := latest[line]; ok && manager.CompareVersions(newest, parsed) > 0 {
+			item.Latest = fmt.Sprintf("%d.%d.%d", newest.Major, newest.Minor, newest.Patch)
+			item.Outdated = true
+		}
+		if release, ok := schedule[line]; ok {
+			item.Status = release.Status(now)
+			if release.Codename != "" {
+				item.Codename = &release.Codename
+			}
+			if release.End != "" {
+				item.EOL = &release.End
+			}
+		}
+		if item.Status == manager.StatusEOL {
+			eol++
+		}
+		output.Versions = append(output.Versions, item)
+	}
+
+	var eolErr error
+	if failOnEOL && eol > 0 {
+		eolErr = withCode(ErrorCodeEOL, i18n.Nerrorf("outdated.eol_found", eol, eol))
+	}
+
+	if StructuredOutput() {
+		if err := writeOutput(output); err != nil {
+			return err
+		}
+		if eolErr != nil {
+			return &reportedError{eolErr}
+		}
+		return nil
+	}
+
+	if len(output.Versions) == 0 {
+		fmt.Println(i18n.T("list.empty"))
+		fmt.Println(i18n.T("list.empty_hint"))
+		return nil
+	}
+
+	// tabwriter alinea las columnas contando caracteres y no bytes, para los encabezados traducidos
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	format := " %s\t%s\t%s\t%s\n"
+	fmt.Fprintf(table, format, i18n.T("outdated.column.version"), i18n.T("outdated.column.latest"), i18n.T("outdated.column.status"), i18n.T("outdated.column.eol"))
+	outdated := 0
+	for _, item := range output.Versions {
+		version := item.Version
+		if item.Current {
+			version = "[" + version + "]"
+		}
+		latest := item.Latest
+		if !item.Outdated {
+			latest = "-"
+		} else {
+			outdated++
+		}
+		status := i18n.T("outdated.status." + item.Status)
+		if item.Codename != nil {
+			status += fmt.Sprintf(" (%s)", *item.Codename)
+		}
+		end := "-"
+		if item.EOL != nil {
+			end = *item.EOL
+		}
+		fmt.Fprintf(table, format, version, latest, status, end)
+	}
+	table.Flush()
+
+	fmt.Println()
+	if outdated == 0 {
+		fmt.Println(i18n.T("outdated.up_to_date"))
+	} else {
+		fmt.Print(i18n.N("outdated.summary", outdated, outdated))
+	}
+	// Con --fail-on-eol la cantidad de versiones se informa en el error
+	if eol > 0 && eolErr == nil {
+		fmt.Print(i18n.N("outdated.eol_summary", eol, eol))
+	}
+	return eolErr
+}
+
+// latestByLine devuelve la versión más nueva del índice de cada línea de versiones ("v20")
+func latestByLine(index []manager.IndexEntry) map[string]shared.Version {
+	latest := map[string]shared.Version{}
+	for _, entry := range index {
+		version, err := manager.ParseVersion(shared.NormalizeVersion(entry.Version))
+		if err != nil {
+			continue
+		}
+		line := manager.ReleaseLineName(version)
+		if best, ok := latest[line]; !ok || manager.CompareVersions(version, best) > 0 {
+			latest[line] = version
+		}
+	}
+	return latest
+}
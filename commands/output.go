@@ -0,0 +1,324 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"polynode/i18n"
+	"polynode/pkg/manager"
+	"strings"
+)
+
+// Formatos de salida admitidos por --output
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+	OutputYAML = "yaml"
+)
+
+/*
+Códigos de error de la salida estructurada. Forman parte del formato documentado,
+por lo que no deben cambiar aunque cambie el texto de los mensajes.
+*/
+const (
+	ErrorCodeUsage         = "usage"
+	ErrorCodeNotFound      = "not-found"
+	ErrorCodeNetwork       = "network"
+	ErrorCodeIntegrity     = "integrity"
+	ErrorCodeInstallFailed = "install-failed"
+	ErrorCodeUnsupported   = "unsupported"
+	ErrorCodeEOL           = "eol"
+	ErrorCodeVulnerable    = "vulnerable"
+	ErrorCodeGeneric       = "error"
+)
+
+var outputFormat = OutputText
+
+// SetOutputFormat selecciona el formato de salida de los comandos
+func SetOutputFormat(format string) error {
+	switch format {
+	case OutputText, OutputJSON, OutputYAML:
+		outputFormat = format
+		return nil
+	}
+	return usageError("output.invalid_format", format)
+}
+
+// StructuredOutput indica si se seleccionó una salida para scripts (json o yaml)
+func StructuredOutput() bool {
+	return outputFormat != OutputText
+}
+
+// UnsupportedOutputError es el error de los comandos que no admiten --output json|yaml
+func UnsupportedOutputError(command string) error {
+	return withCode(ErrorCodeUnsupported, i18n.Errorf("output.unsupported", command))
+}
+
+// commandError asocia un código estable a un error para la salida estructurada
+type commandError struct {
+	Code string
+	Err  error
+}
+
+func (e *commandError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *commandError) Unwrap() error {
+	return e.Err
+}
+
+func withCode(code string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &commandError{Code: code, Err: err}
+}
+
+// usageError crea un error de parámetros inválidos con el mensaje del catálogo indicado por key
+func usageError(key string, args ...interface{}) error {
+	return withCode(ErrorCodeUsage, i18n.Errorf(key, args...))
+}
+
+/*
+errorCode devuelve el código de un error: el asociado con withCode o, si no tiene, el de la
+categoría del error del manager. Los demás errores usan ErrorCodeGeneric.
+*/
+func errorCode(err error) string {
+	var coded *commandError
+	if errors.As(err, &coded) {
+		return coded.Code
+	}
+	return errorCategory(err)
+}
+
+// errorCategory devuelve el código que corresponde a la categoría de un error del manager
+func errorCategory(err error) string {
+	switch {
+	case errors.Is(err, manager.ErrNotFound):
+		return ErrorCodeNotFound
+	case errors.Is(err, manager.ErrNetwork):
+		return ErrorCodeNetwork
+	case errors.Is(err, manager.ErrIntegrity):
+		return ErrorCodeIntegrity
+	}
+	return ErrorCodeGeneric
+}
+
+/*
+reportedError es un error cuyo detalle ya se incluyó en la salida estructurada (por ejemplo,
+los resultados de una instalación con fallos); sólo determina el código de salida.
+*/
+type reportedError struct {
+	error
+}
+
+func (e *reportedError) Unwrap() error {
+	return e.error
+}
+
+// outputError es la representación de un error en la salida estructurada
+type outputError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func newOutputError(err error) *outputError {
+	if err == nil {
+		return nil
+	}
+	return &outputError{Code: errorCode(err), Message: err.Error()}
+}
+
+// PrintError muestra un error en el formato de salida seleccionado
+func PrintError(err error) {
+	var reported *reportedError
+	if errors.As(err, &reported) {
+		return
+	}
+	if !StructuredOutput() {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	writeOutput(struct {
+		Error *outputError `json:"error"`
+	}{newOutputError(err)})
+}
+
+/*
+messageOutput es el destino de los mensajes informativos: se descartan con --quiet y van a
+stderr cuando stdout lleva la salida estructurada.
+*/
+func messageOutput() io.Writer {
+	if Quiet() {
+		return io.Discard
+	}
+	if StructuredOutput() {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// writeOutput escribe un valor en stdout en el formato de salida seleccionado (json o yaml)
+func writeOutput(value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "    ")
+	if err != nil {
+		return i18n.Errorf("output.encode_error", err)
+	}
+
+	if outputFormat == OutputYAML {
+		data, err = jsonToYAML(data)
+		if err != nil {
+			return i18n.Errorf("output.encode_error", err)
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+
+	_, err = fmt.Println(string(data))
+	return err
+}
+
+/*
+yamlMap es un objeto JSON decodificado conservando el orden de las claves, para que la salida
+YAML siga el mismo orden que la salida JSON.
+*/
+type yamlMap struct {
+	keys   []string
+	values []interface{}
+}
+
+// jsonToYAML convierte un documento JSON en YAML
+func jsonToYAML(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	value, err := decodeOrdered(decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	var sb strings.Builder
+	switch value.(type) {
+	case *yamlMap, []interface{}:
+		writeYAML(&sb, value, 0)
+	default:
+		sb.WriteString(yamlScalar(value) + "\n")
+	}
+	return []byte(sb.String()), nil
+}
+
+func decodeOrdered(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		object := &yamlMap{}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			object.keys = append(object.keys, key.(string))
+			object.values = append(object.values, value)
+		}
+		_, err = decoder.Token()
+		return object, err
+	case json.Delim('['):
+		list := []interface{}{}
+		for decoder.More() {
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		_, err = decoder.Token()
+		return list, err
+	}
+	return token, nil
+}
+
+// writeYAML escribe un objeto o una lista con la indentación indicada
+func writeYAML(sb *strings.Builder, value interface{}, indent int) {
+	prefix := strings.Repeat(" ", indent)
+
+	switch value := value.(type) {
+	case *yamlMap:
+		for i, key := range value.keys {
+			sb.WriteString(prefix + key + ":")
+			writeYAMLValue(sb, value.values[i], indent+2)
+		}
+	case []interface{}:
+		for _, item := range value {
+			// Los elementos que son objetos empiezan en la misma línea que el guión
+			var child strings.Builder
+			switch item.(type) {
+			case *yamlMap, []interface{}:
+				if isEmptyYAML(item) {
+					child.WriteString(prefix + "  " + yamlScalar(item) + "\n")
+				} else {
+					writeYAML(&child, item, indent+2)
+				}
+			default:
+				child.WriteString(prefix + "  " + yamlScalar(item) + "\n")
+			}
+			sb.WriteString(prefix + "- " + strings.TrimPrefix(child.String(), prefix+"  "))
+		}
+	}
+}
+
+// writeYAMLValue escribe el valor de una clave: en la misma línea si es simple o vacío, o en las líneas siguientes
+func writeYAMLValue(sb *strings.Builder, value interface{}, indent int) {
+	switch value.(type) {
+	case *yamlMap, []interface{}:
+		if !isEmptyYAML(value) {
+			sb.WriteString("\n")
+			writeYAML(sb, value, indent)
+			return
+		}
+	}
+	sb.WriteString(" " + yamlScalar(value) + "\n")
+}
+
+func isEmptyYAML(value interface{}) bool {
+	switch value := value.(type) {
+	case *yamlMap:
+		return len(value.keys) == 0
+	case []interface{}:
+		return len(value) == 0
+	}
+	return false
+}
+
+// yamlScalar representa un valor simple; los textos se escriben entre comillas con el escape de JSON, que es válido en YAML
+func yamlScalar(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		if value {
+			return "true"
+		}
+		return "false"
+	case json.Number:
+		return value.String()
+	case string:
+		quoted, _ := json.Marshal(value)
+		return string(quoted)
+	case *yamlMap:
+		return "{}"
+	case []interface{}:
+		return "[]"
+	}
+	return fmt.Sprint(value)
+}
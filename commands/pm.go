@@ -0,0 +1,221 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"polynode/i18n"
+	"polynode/pkg/manager"
+	"polynode/shared"
+	"strings"
+)
+
+// packageManagers son los gestores de paquetes que se administran con Corepack
+var packageManagers = []string{"pnpm", "yarn"}
+
+// packageManagerShims son los ejecutables que Corepack crea para cada gestor de paquetes
+var packageManagerShims = map[string][]string{
+	"pnpm": {"pnpm", "pnpx"},
+	"yarn": {"yarn", "yarnpkg"},
+}
+
+/*
+ExecutePm administra los gestores de paquetes (pnpm y Yarn) de una versión de Node con el
+Corepack incluido en esa versión: "use" crea los shims del gestor en el directorio de la
+versión y activa la versión indicada del gestor; "disable" elimina los shims.
+*/
+func ExecutePm(args []string) error {
+	if len(args) < 1 {
+		return usageError("cli.command_usage", placeholders("pm <use|disable> [gestor[@versión]] [--node <versión>]"))
+	}
+
+	node := ""
+	var specs []string
+	for i := 1; i < len(args); i++ {
+		if args[i] == "--node" {
+			if i+1 >= len(args) {
+				return usageError("cli.missing_flag_value", args[i])
+			}
+			node = args[i+1]
+			i++
+			continue
+		}
+		specs = append(specs, args[i])
+	}
+
+	switch args[0] {
+	case "use":
+		if len(specs) > 1 {
+			return usageError("cli.command_usage", placeholders("pm use [gestor[@versión]] [--node <versión>]"))
+		}
+		var pm npmPackage
+		if len(specs) == 1 {
+			pm = parseNpmPackage(specs[0])
+		} else {
+			// Sin parámetros se usa el gestor indicado en el campo packageManager del package.json del proyecto
+			project, path, ok := projectPackageManager()
+			if !ok {
+				return usageError("pm.no_project")
+			}
+			pm = project
+			printInfo("pm.project", pm.Spec(), path)
+		}
+		if err := validatePackageManager(pm.Name); err != nil {
+			return err
+		}
+
+		version, err := pmNodeVersion(node)
+		if err != nil {
+			return err
+		}
+		if err := enablePackageManager(version, pm); err != nil {
+			return err
+		}
+		printInfo("pm.enabled", pm.Spec(), version)
+		return nil
+
+	case "disable":
+		names := specs
+		if len(names) == 0 {
+			names = packageManagers
+		}
+		for _, name := range names {
+			if err := validatePackageManager(name); err != nil {
+				return err
+			}
+		}
+
+		version, err := pmNodeVersion(node)
+		if err != nil {
+			return err
+		}
+		versionPath := versionInstallPath(version)
+		if err := runCorepack(version, append([]string{"disable", "--install-directory", shared.GetBinPath(versionPath)}, names...)...); err != nil {
+			return err
+		}
+		printInfo("pm.disabled", strings.Join(names, ", "), version)
+		return nil
+	}
+
+	return usageError("cli.unknown_subcommand", "pm", args[0], "")
+}
+
+// validatePackageManager comprueba que el gestor de paquetes se pueda administrar con Corepack
+func validatePackageManager(name string) error {
+	for _, pm := range packageManagers {
+		if pm == name {
+			return nil
+		}
+	}
+	return usageError("pm.unknown", name, strings.Join(packageManagers, ", "))
+}
+
+// pmNodeVersion devuelve la versión de Node indicada con --node o, si no se indicó, la versión actual
+func pmNodeVersion(spec string) (string, error) {
+	if spec != "" {
+		return manager.ResolveInstalled(spec)
+	}
+	version := shared.GetCurrentVersion()
+	if version == "" {
+		return "", i18n.Errorf("shell.no_version")
+	}
+	return version, nil
+}
+
+/*
+enablePackageManager crea los shims del gestor de paquetes en el directorio de ejecutables de
+la versión y, si se indicó una versión del gestor, la activa como la versión por defecto de Corepack.
+*/
+func enablePackageManager(version string, pm npmPackage) error {
+	versionPath := versionInstallPath(version)
+	if err := runCorepack(version, "enable", "--install-directory", shared.GetBinPath(versionPath), pm.Name); err != nil {
+		return err
+	}
+	if pm.Version == "" {
+		return nil
+	}
+	return runCorepack(version, "prepare", pm.Spec(), "--activate")
+}
+
+// runCorepack ejecuta el Corepack incluido en una versión con su propio node, de modo que no dependa del PATH
+func runCorepack(version string, args ...string) error {
+	versionPath := versionInstallPath(version)
+	corepackCli := shared.GetCorepackCli(versionPath)
+	if _, err := os.Stat(corepackCli); err != nil {
+		return i18n.Errorf("pm.no_corepack", version)
+	}
+
+	cmd := exec.Command(shared.GetNodeExecutable(versionPath), append([]string{corepackCli}, args...)...)
+	cmd.Stdout = messageOutput()
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return i18n.Errorf("pm.corepack_error", err)
+	}
+	return nil
+}
+
+// packageManagerEnabled indica si los shims del gestor de paquetes existen en el directorio de la versión
+func packageManagerEnabled(version, name string) bool {
+	shim := filepath.Join(shared.GetBinPath(versionInstallPath(version)), packageManagerShims[name][0])
+	// Los shims dependen de la plataforma de las versiones instaladas, no de la del ejecutable
+	if shared.IsWindows() {
+		shim += ".cmd"
+	}
+	_, err := os.Stat(shim)
+	return err == nil
+}
+
+/*
+projectPackageManager busca el package.json más cercano en el directorio actual o en alguno de
+sus padres y devuelve el gestor de paquetes de su campo packageManager ("pnpm@9.1.0") y la ruta
+del archivo. Como en npm, sólo se tiene en cuenta el package.json más cercano.
+*/
+func projectPackageManager() (npmPackage, string, bool) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return npmPackage{}, "", false
+	}
+
+	for {
+		candidate := filepath.Join(dir, "package.json")
+		if body, err := os.ReadFile(candidate); err == nil {
+			var project struct {
+				PackageManager string `json:"packageManager"`
+			}
+			if json.Unmarshal(body, &project) != nil || project.PackageManager == "" {
+				return npmPackage{}, "", false
+			}
+			return parseNpmPackage(project.PackageManager), candidate, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return npmPackage{}, "", false
+		}
+		dir = parent
+	}
+}
+
+/*
+ensureProjectPackageManager habilita en una versión el gestor de paquetes del proyecto actual si
+todavía no tiene sus shims. No activa la versión del gestor: Corepack usa la del campo
+packageManager al ejecutarse dentro del proyecto. Los errores se muestran como advertencia.
+*/
+func ensureProjectPackageManager(version string) {
+	pm, path, ok := projectPackageManager()
+	if !ok || validatePackageManager(pm.Name) != nil || packageManagerEnabled(version, pm.Name) {
+		return
+	}
+
+	printInfo("pm.project", pm.Spec(), path)
+	if err := enablePackageManager(version, npmPackage{Name: pm.Name}); err != nil {
+		fmt.Fprint(os.Stderr, i18n.T("pm.project_warning", pm.Name, err))
+	}
+}
+
+// completePackageManagers devuelve los gestores de paquetes administrados con Corepack
+func completePackageManagers() []string {
+	return packageManagers
+}
@@ -0,0 +1,238 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"polynode/i18n"
+	"polynode/pkg/manager"
+	"polynode/shared"
+	"strings"
+	"sync"
+	"time"
+)
+
+// progressLine arma el texto de la barra de progreso de una descarga
+func progressLine(fileName string, current, total int64) string {
+	currentMB := float64(current) / (1024 * 1024)
+	if total <= 0 {
+		return fmt.Sprintf("%s (%.1f MB)", fileName, currentMB)
+	}
+
+	percentage := float64(current) / float64(total) * 100
+	barLength := 30
+	filledLength := int(float64(barLength) * percentage / 100)
+
+	bar := "["
+	for i := 0; i < barLength; i++ {
+		if i < filledLength {
+			bar += "="
+		} else if i == filledLength {
+			bar += ">"
+		} else {
+			bar += " "
+		}
+	}
+	bar += "]"
+
+	// Calcular tamaños en MB
+	totalMB := float64(total) / (1024 * 1024)
+
+	return fmt.Sprintf("%s %s %.1f%% (%.1f/%.1f MB)", fileName, bar, percentage, currentMB, totalMB)
+}
+
+// progressBar es el estado de una línea de MultiProgress
+type progressBar struct {
+	fileName string
+	current  int64
+	total    int64
+	status   string
+}
+
+func (b *progressBar) line() string {
+	if b.status != "" {
+		return fmt.Sprintf("%s %s", b.fileName, b.status)
+	}
+	return progressLine(b.fileName, b.current, b.total)
+}
+
+/*
+MultiProgress muestra varias barras de progreso a la vez, una por línea,
+redibujándolas en el lugar con secuencias de escape ANSI. Si las secuencias ANSI
+están desactivadas (--no-color), sólo muestra una línea por cada cambio de estado.
+*/
+type MultiProgress struct {
+	mu       sync.Mutex
+	bars     []*progressBar
+	byName   map[string]*progressBar
+	drawn    int
+	lastDraw time.Time
+	plain    bool
+}
+
+func NewMultiProgress() *MultiProgress {
+	return &MultiProgress{byName: map[string]*progressBar{}, plain: !ansiEnabled()}
+}
+
+// bar devuelve la línea de un archivo, agregándola si no existe; se llama con mu tomado
+func (mp *MultiProgress) bar(fileName string) *progressBar {
+	bar, ok := mp.byName[fileName]
+	if !ok {
+		bar = &progressBar{fileName: fileName}
+		mp.byName[fileName] = bar
+		mp.bars = append(mp.bars, bar)
+	}
+	return bar
+}
+
+// Update actualiza el avance de la descarga de un archivo
+func (mp *MultiProgress) Update(fileName string, current, total int64) {
+	mp.mu.Lock()
+	bar := mp.bar(fileName)
+	bar.current, bar.total = current, total
+	mp.mu.Unlock()
+	if !mp.plain {
+		mp.render(false)
+	}
+}
+
+// SetStatus reemplaza la barra de progreso por un texto de estado (por ejemplo, "extrayendo")
+func (mp *MultiProgress) SetStatus(fileName, status string) {
+	mp.mu.Lock()
+	bar := mp.bar(fileName)
+	bar.status = status
+	if mp.plain {
+		fmt.Println(bar.line())
+		mp.mu.Unlock()
+		return
+	}
+	mp.mu.Unlock()
+	mp.render(true)
+}
+
+// Message muestra un mensaje por encima de las barras de progreso
+func (mp *MultiProgress) Message(text string) {
+	if mp.plain {
+		fmt.Println(text)
+		return
+	}
+	mp.mu.Lock()
+	if mp.drawn > 0 {
+		fmt.Printf("\033[%dA\r\033[J", mp.drawn)
+		mp.drawn = 0
+	}
+	fmt.Println(text)
+	mp.mu.Unlock()
+	mp.render(true)
+}
+
+// Finish dibuja el estado final de todas las barras
+func (mp *MultiProgress) Finish() {
+	if mp.plain {
+		return
+	}
+	mp.render(true)
+}
+
+func (mp *MultiProgress) render(force bool) {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+
+	// Limitar la frecuencia de redibujado para no saturar la consola
+	if !force && time.Since(mp.lastDraw) < 100*time.Millisecond {
+		return
+	}
+	mp.lastDraw = time.Now()
+
+	var sb strings.Builder
+	if mp.drawn > 0 {
+		sb.WriteString(fmt.Sprintf("\033[%dA", mp.drawn))
+	}
+	for _, bar := range mp.bars {
+		sb.WriteString("\r\033[2K")
+		sb.WriteString(bar.line())
+		sb.WriteString("\n")
+	}
+	mp.drawn = len(mp.bars)
+	fmt.Print(sb.String())
+}
+
+/*
+consoleReporter muestra en la consola los eventos y el avance de las operaciones del manager.
+Con display, cada archivo ocupa una línea de MultiProgress; sin display se muestra un mensaje
+por paso y la barra de la descarga en curso.
+*/
+type consoleReporter struct {
+	display *MultiProgress
+}
+
+func newConsoleReporter(multi bool) *consoleReporter {
+	if multi {
+		return &consoleReporter{display: NewMultiProgress()}
+	}
+	return &consoleReporter{}
+}
+
+func (r *consoleReporter) Event(event manager.Event) {
+	// Con --quiet sólo se muestran las advertencias
+	if Quiet() {
+		if event.Kind == manager.EventWarning {
+			fmt.Fprintln(os.Stderr, event.Message)
+		}
+		return
+	}
+
+	if r.display != nil {
+		switch event.Kind {
+		case manager.EventCacheHit:
+			r.display.SetStatus(event.File, i18n.T("progress.cached"))
+		case manager.EventExtract:
+			r.display.SetStatus(event.File, i18n.T("progress.extracting"))
+		case manager.EventInstalled:
+			r.display.SetStatus(event.File, i18n.T("progress.installed"))
+		case manager.EventInfo, manager.EventWarning:
+			r.display.Message(event.Message)
+		case manager.EventDownload:
+			if Verbose() {
+				r.display.Message(i18n.T("progress.downloading_url", event.Message))
+			}
+		}
+		return
+	}
+
+	switch event.Kind {
+	case manager.EventResolved:
+		fmt.Print(i18n.T("progress.resolved", event.Spec, event.Version))
+	case manager.EventCacheHit:
+		fmt.Print(i18n.T("bundle.using_cache", event.File))
+	case manager.EventDownload:
+		fmt.Print(i18n.T("progress.downloading", event.Message))
+	case manager.EventExtract:
+		fmt.Println(i18n.T("offline.extracting"))
+	case manager.EventInstalled:
+		fmt.Print(i18n.T("offline.installed", event.Version, shared.GetInstallPath()))
+	default:
+		fmt.Println(event.Message)
+	}
+}
+
+func (r *consoleReporter) Progress(progress manager.Progress) {
+	if Quiet() {
+		return
+	}
+
+	if r.display != nil {
+		r.display.Update(progress.File, progress.Current, progress.Total)
+		return
+	}
+
+	if progress.Total <= 0 {
+		return
+	}
+
+	// Limpiar línea anterior y mostrar progreso
+	fmt.Printf("\r%s", progressLine(progress.File, progress.Current, progress.Total))
+
+	if progress.Current >= progress.Total {
+		fmt.Println() // Nueva línea al completar
+	}
+}
@@ -1,33 +1,180 @@
 package commands
 
 import (
-	"encoding/json"
+	"bufio"
 	"fmt"
 	"os"
-	"path/filepath"
+	"os/signal"
+	"polynode/i18n"
 	"polynode/shared"
+	"strings"
 )
 
+func ExecuteProxy(args []string) error {
+	if len(args) == 0 {
+		return showProxyConfig()
+	}
+
+	switch args[0] {
+	case "--https":
+		if len(args) < 2 {
+			return usageError("cli.command_usage", "proxy --https <url>")
+		}
+		if err := shared.SetUserConfigValue("https_proxy", args[1]); err != nil {
+			return i18n.Errorf("proxy.config_error", err)
+		}
+		fmt.Print(i18n.T("proxy.https_updated", args[1]))
+		return nil
+
+	case "--bypass":
+		if len(args) < 2 {
+			return usageError("cli.command_usage", "proxy --bypass <host1,host2,...>")
+		}
+		if err := shared.SetUserConfigValue("no_proxy", args[1]); err != nil {
+			return i18n.Errorf("proxy.config_error", err)
+		}
+		fmt.Print(i18n.T("proxy.bypass_updated", args[1]))
+		return nil
+
+	case "--pac":
+		if len(args) < 2 {
+			return usageError("cli.command_usage", placeholders("proxy --pac <url|archivo>"))
+		}
+		if err := shared.SetUserConfigValue("proxy_pac", args[1]); err != nil {
+			return i18n.Errorf("proxy.config_error", err)
+		}
+		fmt.Print(i18n.T("proxy.pac_updated", args[1]))
+		return nil
+
+	case "--user":
+		if len(args) < 2 {
+			return usageError("cli.command_usage", placeholders("proxy --user <usuario>[:<contraseña>]"))
+		}
+		return setProxyCredentials(args[1])
+
+	case "--clear":
+		return clearProxyConfig()
+	}
+
+	if err := SetProxyURL(args[0]); err != nil {
+		return i18n.Errorf("proxy.config_error", err)
+	}
+	return nil
+}
+
 func SetProxyURL(httpProxy string) error {
-	proxyConfigFile := filepath.Join(shared.GetInstallPath(), "proxy.json")
+	// Guardar la URL del proxy en la configuración del usuario
+	if err := shared.SetUserConfigValue("http_proxy", httpProxy); err != nil {
+		return i18n.Errorf("proxy.save_error", err)
+	}
+
+	fmt.Print(i18n.T("proxy.updated", httpProxy))
+	return nil
+}
 
-	// Crear la configuración del proxy
-	proxyConfig := shared.ProxyConfig{
-		HTTPProxy: httpProxy,
+func setProxyCredentials(value string) error {
+	user, password, hasPassword := strings.Cut(value, ":")
+	if user == "" {
+		return i18n.Errorf("proxy.missing_user")
 	}
 
-	// Serializar la configuración del proxy a JSON
-	proxyJSON, err := json.MarshalIndent(proxyConfig, "", "    ")
-	if err != nil {
-		return fmt.Errorf("Error al serializar la configuración del proxy: %w", err)
+	if !hasPassword {
+		// Solicitar la contraseña para no dejarla en el historial de comandos
+		var err error
+		if password, err = readProxyPassword(user); err != nil {
+			return err
+		}
 	}
 
-	// Escribir la configuración del proxy en el archivo proxy.json
-	err = os.WriteFile(proxyConfigFile, proxyJSON, 0644)
+	credentials := shared.Credentials{ProxyUser: user, ProxyPassword: password}
+	if err := shared.SaveCredentials(credentials); err != nil {
+		return err
+	}
+
+	fmt.Print(i18n.T("proxy.credentials_saved", shared.GetCredentialsFilePath()))
+	return nil
+}
+
+// proxyPasswordEnv es la variable de entorno con la contraseña del proxy, para usar desde scripts
+const proxyPasswordEnv = "POLYNODE_PROXY_PASSWORD"
+
+/*
+readProxyPassword obtiene la contraseña del proxy de la variable POLYNODE_PROXY_PASSWORD o de la
+entrada estándar. En una terminal se solicita sin mostrar lo que se escribe; si no se puede
+desactivar el eco, no se solicita para no dejar la contraseña a la vista.
+*/
+func readProxyPassword(user string) (string, error) {
+	if password := os.Getenv(proxyPasswordEnv); password != "" {
+		return password, nil
+	}
+
+	info, err := os.Stdin.Stat()
+	terminal := err == nil && info.Mode()&os.ModeCharDevice != 0
+	if terminal {
+		if err := setEcho(false); err != nil {
+			return "", i18n.Errorf("proxy.password_echo_error", err, proxyPasswordEnv)
+		}
+		// Con Ctrl+C se restaura el eco antes de terminar, para no dejar la terminal sin eco
+		interrupt := make(chan os.Signal, 1)
+		signal.Notify(interrupt, os.Interrupt)
+		defer signal.Stop(interrupt)
+		go func() {
+			if _, ok := <-interrupt; ok {
+				setEcho(true)
+				fmt.Println()
+				os.Exit(ExitError)
+			}
+		}()
+		fmt.Print(i18n.T("proxy.password_prompt", user))
+	}
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if terminal {
+		setEcho(true)
+		// El salto de línea que escribió el usuario no se mostró
+		fmt.Println()
+	}
+	if err != nil && line == "" {
+		return "", i18n.Errorf("proxy.password_error", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func clearProxyConfig() error {
+	for _, key := range []string{"http_proxy", "https_proxy", "no_proxy", "proxy_pac"} {
+		if err := shared.UnsetUserConfigValue(key); err != nil {
+			return err
+		}
+	}
+	if err := shared.SaveCredentials(shared.Credentials{}); err != nil {
+		return err
+	}
+
+	fmt.Println(i18n.T("proxy.cleared"))
+	return nil
+}
+
+func showProxyConfig() error {
+	credentials, err := shared.LoadCredentials()
 	if err != nil {
-		return fmt.Errorf("Error al escribir la configuración del proxy en el archivo: %w", err)
+		return err
+	}
+
+	for _, key := range []string{"http_proxy", "https_proxy", "no_proxy", "proxy_pac"} {
+		value, source := shared.GetConfigValue(key)
+		if value == "" {
+			value = "-"
+		}
+		fmt.Printf(" %-12s %s (%s)\n", key, value, source)
+	}
+
+	if credentials.ProxyUser != "" {
+		fmt.Printf(" %-12s %s\n", i18n.T("arg.usuario"), credentials.ProxyUser)
+	}
+
+	if shared.GetConfig("http_proxy") == "" && shared.GetConfig("https_proxy") == "" && shared.GetConfig("proxy_pac") == "" {
+		fmt.Println(i18n.T("proxy.none"))
 	}
 
-	fmt.Printf("Se actualizó la configuración para usar el proxy '%s'\n", httpProxy)
 	return nil
 }
@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"fmt"
+	"polynode/i18n"
+	"polynode/pkg/manager"
+	"polynode/shared"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// pruneCandidate es una versión instalada que cumple la política de "poly prune"
+type pruneCandidate struct {
+	Version  string
+	LastUsed time.Time
+	Size     int64
+}
+
+/*
+ExecutePrune desinstala las versiones que cumplen la política indicada: con --keep <n> las que no
+están entre las n más nuevas de su versión mayor, y con --unused <duración> las que no se usaron
+en ese tiempo (según la fecha que guardan "use" y "shell"). Si se indican las dos, se eliminan
+las versiones que cumplen ambas. La versión actual y las versiones con alias nunca se eliminan.
+Con --dry-run sólo se muestra qué versiones se eliminarían y el espacio que se liberaría.
+*/
+func ExecutePrune(args []string) error {
+	keep := -1
+	var unused time.Duration
+	dryRun := false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--keep":
+			if i+1 >= len(args) {
+				return usageError("cli.missing_flag_value", args[i])
+			}
+			n, err := strconv.Atoi(args[i+1])
+			if err != nil || n < 1 {
+				return usageError("cli.invalid_flag_value", args[i], args[i+1])
+			}
+			keep = n
+			i++
+		case "--unused":
+			if i+1 >= len(args) {
+				return usageError("cli.missing_flag_value", args[i])
+			}
+			duration, err := shared.ParseDuration(args[i+1])
+			if err != nil || duration <= 0 {
+				return usageError("cli.invalid_flag_value", args[i], args[i+1])
+			}
+			unused = duration
+			i++
+		case "--dry-run":
+			dryRun = true
+		default:
+			return usageError("cli.command_usage", placeholders("prune [--keep <n>] [--unused <duración>] [--dry-run]"))
+		}
+	}
+	if keep < 0 && unused == 0 {
+		return usageError("prune.no_policy")
+	}
+
+	candidates, err := pruneCandidates(keep, unused)
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		printInfo("prune.nothing")
+		return nil
+	}
+
+	var total int64
+	if dryRun {
+		printInfo("prune.dry_run_title")
+	} else {
+		printInfo("prune.title")
+	}
+	for _, candidate := range candidates {
+		printInfo("prune.candidate", candidate.Version, candidate.LastUsed.Local().Format("2006-01-02"), shared.FormatSize(candidate.Size))
+		total += candidate.Size
+	}
+	printInfo("prune.total", shared.FormatSize(total))
+	if dryRun {
+		return nil
+	}
+
+	if !confirm(i18n.T("prune.confirm")) {
+		printInfo("prune.cancelled")
+		return nil
+	}
+	for _, candidate := range candidates {
+		if err := UninstallNodeVersion(candidate.Version); err != nil {
+			return i18n.Errorf("uninstall.failed", err)
+		}
+	}
+	fmt.Fprint(messageOutput(), i18n.N("prune.done", len(candidates), len(candidates), shared.FormatSize(total)))
+	return nil
+}
+
+// pruneCandidates devuelve las versiones instaladas que cumplen la política, de la más antigua a la más nueva
+func pruneCandidates(keep int, unused time.Duration) ([]pruneCandidate, error) {
+	versions, err := listInstalledVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	// Las versiones con alias y la actual se conservan siempre
+	protected := map[string]bool{}
+	for version := range aliasesByVersion() {
+		protected[version] = true
+	}
+	if current, _ := shared.CurrentVersion(); current != "" {
+		protected[current] = true
+	}
+
+	// Posición de cada versión dentro de su versión mayor, empezando por la más nueva
+	rank := map[string]int{}
+	byLine := map[string][]shared.Version{}
+	for _, version := range versions {
+		if parsed, err := manager.ParseVersion(version); err == nil {
+			line := manager.ReleaseLineName(parsed)
+			byLine[line] = append(byLine[line], parsed)
+		}
+	}
+	for _, line := range byLine {
+		sort.Slice(line, func(i, j int) bool {
+			return manager.CompareVersions(line[i], line[j]) > 0
+		})
+		for i, version := range line {
+			rank[fmt.Sprintf("%d.%d.%d", version.Major, version.Minor, version.Patch)] = i
+		}
+	}
+
+	var candidates []pruneCandidate
+	for _, version := range versions {
+		if protected[version] {
+			continue
+		}
+		if position, ok := rank[version]; keep > 0 && (!ok || position < keep) {
+			continue
+		}
+		lastUsed, err := manager.LastUsed(version)
+		if err != nil {
+			return nil, err
+		}
+		if unused > 0 && time.Since(lastUsed) < unused {
+			continue
+		}
+		size, _ := manager.DirSize(shared.GetVersionPath(version))
+		candidates = append(candidates, pruneCandidate{Version: version, LastUsed: lastUsed, Size: size})
+	}
+	return candidates, nil
+}
@@ -0,0 +1,282 @@
+package commands
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"polynode/i18n"
+	"polynode/pkg/manager"
+	"polynode/shared"
+	"strings"
+)
+
+// backupContents resume el contenido de una copia de seguridad
+type backupContents struct {
+	Files    int
+	Size     uint64
+	Dirs     map[string]bool
+	Verified bool
+}
+
+func ExecuteRestore(args []string) error {
+	source := ""
+	assumeYes := AssumeYes()
+	for _, arg := range args {
+		switch arg {
+		case "--yes", "-y":
+			assumeYes = true
+		default:
+			source = arg
+		}
+	}
+	if source == "" {
+		return usageError("cli.command_usage", placeholders("restore <archivo> [--yes]"))
+	}
+	return RestoreInstallation(source, assumeYes)
+}
+
+/*
+RestoreInstallation reemplaza los directorios current y repository por el contenido de una
+copia de seguridad creada con "poly backup". El contenido se extrae primero en un directorio
+temporal del espacio de trabajo, se verifica contra el manifiesto y luego se intercambia con
+renombres, de modo que ante un error se conserva la instalación anterior. Antes del intercambio
+se guarda una copia de seguridad del estado actual.
+*/
+func RestoreInstallation(source string, assumeYes bool) error {
+	if _, err := os.Stat(source); err != nil {
+		return i18n.Errorf("restore.open_error", source, err)
+	}
+
+	installPath := shared.GetInstallPath()
+	staging, err := os.MkdirTemp(installPath, ".restore-")
+	if err != nil {
+		return i18n.Errorf("offline.temp_error", err)
+	}
+	defer os.RemoveAll(staging)
+
+	fmt.Println(i18n.T("restore.verifying"))
+	contents, err := extractBackup(source, staging)
+	if err != nil {
+		return i18n.Errorf("restore.invalid", source, err)
+	}
+
+	printRestorePlan(source, staging, contents)
+	if !assumeYes && !confirm(i18n.T("restore.confirm")) {
+		fmt.Println(i18n.T("restore.cancelled"))
+		return nil
+	}
+
+	safetyBackup, err := createBackup(backupOptions{Format: shared.GetConfig("backup_format")})
+	if err != nil {
+		return i18n.Errorf("restore.safety_error", err)
+	}
+	fmt.Print(i18n.T("restore.safety_saved", safetyBackup))
+
+	if err := swapDirs(installPath, staging, contents.Dirs); err != nil {
+		return err
+	}
+
+	fmt.Print(i18n.T("restore.done", source))
+	return nil
+}
+
+/*
+extractBackup extrae la copia de seguridad en dest, rechazando entradas fuera de current y
+repository, y verifica el SHA-256 de cada archivo si la copia incluye un manifiesto.
+*/
+func extractBackup(source, dest string) (*backupContents, error) {
+	contents := &backupContents{Dirs: map[string]bool{}}
+	hashes := map[string]string{}
+	var manifest *backupManifest
+
+	err := readBackup(source, func(entry backupEntry, r io.Reader) error {
+		// Las comprobaciones se hacen sobre la ruta normalizada, que es la que se escribe
+		name := path.Clean(entry.Name)
+		if name == path.Join(backupRootName, backupManifestName) {
+			manifest = &backupManifest{}
+			return json.NewDecoder(r).Decode(manifest)
+		}
+
+		parts := strings.Split(name, "/")
+		if len(parts) < 2 || parts[0] != backupRootName || !containsString(backupDirs, parts[1]) {
+			return i18n.Errorf("restore.unexpected_file", entry.Name)
+		}
+
+		target, err := manager.SafeJoin(dest, filepath.FromSlash(strings.Join(parts[1:], "/")))
+		if err != nil {
+			return err
+		}
+		// No se permite escribir a través de un enlace simbólico extraído previamente
+		if err := manager.CheckNoSymlinks(dest, target, entry.Name); err != nil {
+			return err
+		}
+		contents.Dirs[parts[1]] = true
+
+		switch {
+		case entry.Mode.IsDir():
+			return os.MkdirAll(target, entry.Mode.Perm()|0700)
+
+		case entry.Mode&os.ModeSymlink != 0:
+			if err := checkBackupLink(dest, target, name, entry.Linkname); err != nil {
+				return err
+			}
+			if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+				return err
+			}
+			return os.Symlink(entry.Linkname, target)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+			return err
+		}
+		// Un enlace previo con el mismo nombre se reemplaza, para no escribir a través de él
+		if info, err := os.Lstat(target); err == nil && info.Mode()&os.ModeSymlink != 0 {
+			if err := os.Remove(target); err != nil {
+				return err
+			}
+		}
+		hash := sha256.New()
+		if err := manager.WriteFile(target, io.TeeReader(r, hash), entry.Mode.Perm()); err != nil {
+			return err
+		}
+		hashes[name] = hex.EncodeToString(hash.Sum(nil))
+		contents.Files++
+		contents.Size += uint64(entry.Size)
+		// Aplicar los permisos originales sin la máscara del proceso
+		return os.Chmod(target, entry.Mode.Perm()|0600)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if contents.Files == 0 {
+		return nil, i18n.Errorf("restore.empty")
+	}
+
+	if manifest == nil {
+		fmt.Println(i18n.T("restore.no_manifest"))
+	} else {
+		for _, file := range manifest.Files {
+			if file.Type != "file" {
+				continue
+			}
+			if hashes[file.Path] != file.SHA256 {
+				return nil, withCode(ErrorCodeIntegrity, i18n.Errorf("restore.checksum_mismatch", file.Path))
+			}
+		}
+		contents.Verified = true
+	}
+
+	return contents, nil
+}
+
+/*
+checkBackupLink rechaza los enlaces simbólicos cuyo destino queda fuera de la copia. La única
+excepción es current con link_mode symlink, que es un enlace absoluto a una versión del
+repositorio del espacio de trabajo.
+*/
+func checkBackupLink(dest, target, name, linkname string) error {
+	repository := filepath.Clean(shared.GetRepoPath()) + string(os.PathSeparator)
+	if name == path.Join(backupRootName, "current") && filepath.IsAbs(linkname) && strings.HasPrefix(filepath.Clean(linkname), repository) {
+		return nil
+	}
+	return manager.CheckLinkTarget(dest, target, name, linkname)
+}
+
+func printRestorePlan(source, staging string, contents *backupContents) {
+	fmt.Print(i18n.N("restore.summary", contents.Files, source, contents.Files, shared.FormatSize(int64(contents.Size))))
+	if contents.Verified {
+		fmt.Println(i18n.T("restore.verified"))
+	}
+
+	if contents.Dirs["current"] {
+		current := shared.GetCurrentVersion()
+		if current == "" {
+			current = i18n.T("restore.none")
+		}
+		fmt.Print(i18n.T("restore.current_replaced", current))
+	} else {
+		fmt.Println(i18n.T("restore.current_kept"))
+	}
+
+	if contents.Dirs["repository"] {
+		fmt.Print(i18n.T("restore.repository_replaced", strings.Join(versionDirs(shared.GetRepoPath()), ", ")))
+		fmt.Print(i18n.T("restore.repository_with", strings.Join(versionDirs(filepath.Join(staging, "repository")), ", ")))
+	} else {
+		fmt.Println(i18n.T("restore.repository_kept"))
+	}
+}
+
+// versionDirs devuelve los nombres de los directorios de versiones de un repositorio
+func versionDirs(repoPath string) []string {
+	var names []string
+	entries, _ := os.ReadDir(repoPath)
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			names = append(names, entry.Name())
+		}
+	}
+	if len(names) == 0 {
+		names = append(names, i18n.T("restore.none"))
+	}
+	return names
+}
+
+/*
+swapDirs reemplaza los directorios del espacio de trabajo por los extraídos en staging. Los
+directorios anteriores se mueven primero a staging y, si algún renombre falla, se vuelven a
+colocar en su lugar.
+*/
+func swapDirs(installPath, staging string, dirs map[string]bool) error {
+	previous := filepath.Join(staging, ".previous")
+	if err := os.MkdirAll(previous, os.ModePerm); err != nil {
+		return err
+	}
+
+	var moved, placed []string
+	rollback := func() {
+		for _, dir := range placed {
+			os.RemoveAll(filepath.Join(installPath, dir))
+		}
+		for _, dir := range moved {
+			os.Rename(filepath.Join(previous, dir), filepath.Join(installPath, dir))
+		}
+	}
+
+	for _, dir := range backupDirs {
+		if !dirs[dir] {
+			continue
+		}
+		target := filepath.Join(installPath, dir)
+		if _, err := os.Lstat(target); err == nil {
+			if err := os.Rename(target, filepath.Join(previous, dir)); err != nil {
+				rollback()
+				return i18n.Errorf("restore.move_error", dir, err)
+			}
+			moved = append(moved, dir)
+		}
+		if err := os.Rename(filepath.Join(staging, dir), target); err != nil {
+			rollback()
+			return i18n.Errorf("restore.restore_error", dir, err)
+		}
+		placed = append(placed, dir)
+	}
+	return nil
+}
+
+// confirm solicita una confirmación por la entrada estándar
+func confirm(question string) bool {
+	if AssumeYes() {
+		return true
+	}
+	fmt.Print(question + i18n.T("confirm.prompt"))
+	line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "s" || answer == "si" || answer == "sí" || answer == "y" || answer == "yes"
+}
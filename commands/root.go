@@ -0,0 +1,320 @@
+package commands
+
+import (
+	"polynode/i18n"
+	"polynode/pkg/manager"
+)
+
+// newRootCommand arma el árbol de comandos de poly; el orden es el que se muestra en la ayuda
+func newRootCommand() *Command {
+	root := &Command{Name: "poly"}
+	root.Subcommands = []*Command{
+		{
+			Name: "install",
+			Args: "<version> ...",
+			Flags: []Flag{
+				{Name: "--from", Value: "<archivo|directorio>"},
+				{Name: "--shasums", Value: "<archivo>"},
+				{Name: "--reinstall-packages-from", Value: "<version>"},
+				{Name: "--exact"},
+			},
+			Structured: true,
+			Run:        ExecuteInstall,
+			Complete:   completeEach(completeRemoteVersions),
+		},
+		{
+			Name: "use",
+			Args: "[version]",
+			Run: func(args []string) error {
+				if len(args) == 0 {
+					if _, ok := manager.LookupAlias(manager.DefaultAlias); !ok {
+						return usageError("use.no_default")
+					}
+					args = []string{manager.DefaultAlias}
+				}
+				if len(args) != 1 {
+					return usageError("cli.command_usage", "use [version]")
+				}
+				version, err := UseNodeVersion(args[0])
+				if err != nil {
+					return err
+				}
+				printInfo("use.changed", version)
+				return nil
+			},
+			Complete: completeFirst(completeInstalledVersions),
+		},
+		{
+			Name:       "list",
+			Structured: true,
+			Run:        noArgs("list", ExecuteList),
+		},
+		{
+			Name:       "version",
+			Structured: true,
+			Run:        noArgs("version", ShowCurrentNodeVersion),
+		},
+		{
+			Name: "ls-remote",
+			Args: "[version]",
+			Flags: []Flag{
+				{Name: "--lts"},
+			},
+			Structured: true,
+			Run:        ExecuteLsRemote,
+			Complete:   completeFirst(completeRemoteVersions),
+		},
+		{
+			Name:       "info",
+			Args:       "<version>",
+			Structured: true,
+			Run:        ExecuteInfo,
+			Complete:   completeFirst(completeRemoteVersions),
+		},
+		{
+			Name: "uninstall",
+			Args: "<version>",
+			Run: func(args []string) error {
+				if len(args) != 1 {
+					return usageError("cli.command_usage", "uninstall <version>")
+				}
+				if err := UninstallNodeVersion(args[0]); err != nil {
+					return i18n.Errorf("uninstall.failed", err)
+				}
+				return nil
+			},
+			Complete: completeFirst(completeInstalledVersions),
+		},
+		{
+			Name: "outdated",
+			Flags: []Flag{
+				{Name: "--fail-on-eol"},
+			},
+			Structured: true,
+			Run:        ExecuteOutdated,
+		},
+		{
+			Name:       "audit",
+			Structured: true,
+			Run:        ExecuteAudit,
+		},
+		{
+			Name: "upgrade",
+			Args: "[version]",
+			Flags: []Flag{
+				{Name: "--patch-only"},
+				{Name: "--prune"},
+			},
+			Run:      ExecuteUpgrade,
+			Complete: completeFirst(completeInstalledVersions),
+		},
+		{
+			Name: "prune",
+			Flags: []Flag{
+				{Name: "--keep", Value: "<n>"},
+				{Name: "--unused", Value: "<duración>"},
+				{Name: "--dry-run"},
+			},
+			Run: ExecutePrune,
+		},
+		{
+			Name: "migrate-globals",
+			Args: "<origen> <destino>",
+			Flags: []Flag{
+				{Name: "--exact"},
+			},
+			Run: ExecuteMigrateGlobals,
+			Complete: func(args []string) []string {
+				if len(args) < 2 {
+					return completeInstalledVersions()
+				}
+				return nil
+			},
+		},
+		{
+			Name: "alias",
+			Subcommands: []*Command{
+				{Name: "set", Args: "<nombre> <version>", Run: delegate(ExecuteAlias, "set"), Complete: completeAliasSet},
+				{Name: "list", Structured: true, Run: delegate(ExecuteAlias, "list")},
+				{Name: "rm", Args: "<nombre>", Run: delegate(ExecuteAlias, "rm"), Complete: completeFirst(completeAliases)},
+				{Name: "refresh", Run: delegate(ExecuteAlias, "refresh")},
+			},
+		},
+		{
+			Name: "pm",
+			Subcommands: []*Command{
+				{
+					Name: "use",
+					Args: "[gestor[@version]]",
+					Flags: []Flag{
+						{Name: "--node", Value: "<version>"},
+					},
+					Run:      delegate(ExecutePm, "use"),
+					Complete: completeFirst(completePackageManagers),
+				},
+				{
+					Name: "disable",
+					Args: "[gestor] ...",
+					Flags: []Flag{
+						{Name: "--node", Value: "<version>"},
+					},
+					Run:      delegate(ExecutePm, "disable"),
+					Complete: completeEach(completePackageManagers),
+				},
+			},
+		},
+		{
+			Name: "proxy",
+			Args: "<url>",
+			Flags: []Flag{
+				{Name: "--https", Value: "<url>"},
+				{Name: "--bypass", Value: "<hosts>"},
+				{Name: "--pac", Value: "<url|archivo>"},
+				{Name: "--user", Value: "<usuario>"},
+				{Name: "--clear"},
+			},
+			Run: ExecuteProxy,
+		},
+		{
+			Name: "config",
+			Subcommands: []*Command{
+				{Name: "list", Run: delegate(ExecuteConfig, "list")},
+				{Name: "get", Args: "<clave>", Run: delegate(ExecuteConfig, "get"), Complete: completeFirst(completeConfigKeys)},
+				{Name: "set", Args: "<clave> <valor>", Run: delegate(ExecuteConfig, "set"), Complete: completeFirst(completeConfigKeys)},
+				{Name: "unset", Args: "<clave>", Run: delegate(ExecuteConfig, "unset"), Complete: completeFirst(completeConfigKeys)},
+			},
+		},
+		{
+			Name: "bundle",
+			Subcommands: []*Command{
+				{
+					Name: "create",
+					Args: "<archivo.tar>",
+					Flags: []Flag{
+						{Name: "--versions", Value: "<v1,v2>"},
+						{Name: "--platforms", Value: "<p1,p2>"},
+					},
+					Run: delegate(ExecuteBundle, "create"),
+				},
+				{Name: "import", Args: "<archivo.tar>", Run: delegate(ExecuteBundle, "import")},
+			},
+		},
+		{
+			Name: "cache",
+			Subcommands: []*Command{
+				{Name: "list", Structured: true, Run: delegate(ExecuteCache, "list")},
+				{
+					Name: "clean",
+					Flags: []Flag{
+						{Name: "--older-than", Value: "<duración>"},
+					},
+					Run: delegate(ExecuteCache, "clean"),
+				},
+			},
+		},
+		{
+			Name: "serve",
+			Flags: []Flag{
+				{Name: "--addr", Value: "<dirección>"},
+				{Name: "--pull-through"},
+			},
+			Run: ExecuteServe,
+		},
+		{
+			Name:       "check",
+			Structured: true,
+			Run:        noArgs("check", CheckInstallation),
+		},
+		{
+			Name: "backup",
+			Flags: []Flag{
+				{Name: "--output", Value: "<destino>"},
+				{Name: "--format", Value: "<formato>", Values: []string{"zip", "tar.gz", "tar.zst"}},
+				{Name: "--level", Value: "<n>"},
+				{Name: "--keep", Value: "<n>"},
+			},
+			Subcommands: []*Command{
+				{Name: "list", Structured: true, Run: noArgs("backup list", listBackups)},
+			},
+			Run: ExecuteBackup,
+		},
+		{
+			Name:     "restore",
+			Args:     "<archivo>",
+			Run:      ExecuteRestore,
+			Complete: completeFirst(completeBackups),
+		},
+		{
+			Name: "export",
+			Args: "[archivo]",
+			Run:  ExecuteExport,
+		},
+		{
+			Name: "import",
+			Args: "<archivo>",
+			Flags: []Flag{
+				{Name: "--with-config"},
+				{Name: "--no-globals"},
+			},
+			Run: ExecuteImport,
+		},
+		{
+			Name: "shell",
+			Run: noArgs("shell", func() error {
+				if err := OpenShell(); err != nil {
+					return i18n.Errorf("shell.error", err)
+				}
+				return nil
+			}),
+		},
+		{
+			Name:     "completion",
+			Args:     "<bash|zsh|fish|powershell>",
+			Run:      ExecuteCompletion,
+			Complete: completeFirst(completionShells),
+		},
+		{
+			Name: "help",
+			Args: "[comando]",
+			Run: func(args []string) error {
+				command := root
+				for _, arg := range args {
+					sub := command.subcommand(arg)
+					if sub == nil {
+						return usageError("cli.unknown_command", arg, suggestion(arg, command.subcommandNames()))
+					}
+					command = sub
+				}
+				showCommandHelp(command)
+				return nil
+			},
+			Complete: func(args []string) []string {
+				command := root
+				for _, arg := range args {
+					if command = command.subcommand(arg); command == nil {
+						return nil
+					}
+				}
+				return command.subcommandNames()
+			},
+		},
+	}
+	return root.link()
+}
+
+// noArgs adapta un comando sin parámetros, rechazando los argumentos de más
+func noArgs(path string, run func() error) func([]string) error {
+	return func(args []string) error {
+		if len(args) > 0 {
+			return usageError("cli.command_usage", path)
+		}
+		return run()
+	}
+}
+
+// delegate ejecuta la función del comando padre con el nombre del subcomando como primer argumento
+func delegate(run func([]string) error, name string) func([]string) error {
+	return func(args []string) error {
+		return run(append([]string{name}, args...))
+	}
+}
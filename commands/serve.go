@@ -0,0 +1,298 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"os"
+	"path"
+	"polynode/i18n"
+	"polynode/pkg/manager"
+	"polynode/shared"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+mirrorServer expone la caché de descargas con la misma estructura que https://nodejs.org/dist/,
+para que otros equipos con polynode la usen como mirror. Con pullThrough, los archivos que no
+están en la caché se descargan del mirror configurado en este equipo.
+*/
+type mirrorServer struct {
+	manager     *manager.Manager
+	pullThrough bool
+	locks       sync.Map
+}
+
+func ExecuteServe(args []string) error {
+	addr := ":8080"
+	pullThrough := false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--addr":
+			if i+1 >= len(args) {
+				return usageError("cli.missing_flag_value", "--addr")
+			}
+			addr = args[i+1]
+			i++
+		case "--pull-through":
+			pullThrough = true
+		default:
+			return usageError("cli.command_usage", placeholders("serve [--addr <dirección>] [--pull-through]"))
+		}
+	}
+
+	// Las descargas del servidor no muestran progreso en la consola
+	server := &mirrorServer{manager: newManager(nil), pullThrough: pullThrough}
+	if pullThrough {
+		if _, err := server.manager.Client(); err != nil {
+			return err
+		}
+		fmt.Print(i18n.T("serve.pull_through", shared.GetNodeRepositoryBaseURL()))
+	}
+
+	fmt.Print(i18n.T("serve.listening", addr))
+	fmt.Println(i18n.T("serve.stop_hint"))
+	return http.ListenAndServe(addr, server)
+}
+
+func (s *mirrorServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	s.route(recorder, r)
+	fmt.Printf("%s %s %s %d %s\n", start.Format("15:04:05"), r.Method, r.URL.Path, recorder.status, time.Since(start).Round(time.Millisecond))
+}
+
+func (s *mirrorServer) route(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, i18n.T("serve.method_not_allowed"), http.StatusMethodNotAllowed)
+		return
+	}
+
+	urlPath := strings.TrimPrefix(path.Clean(r.URL.Path), "/")
+	dir, name := path.Split(urlPath)
+	dir = strings.TrimSuffix(dir, "/")
+
+	switch {
+	case urlPath == "" || urlPath == ".":
+		s.serveRootListing(w)
+	case urlPath == "index.json":
+		s.serveIndexJSON(w, r)
+	case urlPath == "index.tab":
+		s.serveIndexTab(w)
+	case dir == "" && strings.HasPrefix(name, "v"):
+		s.serveVersionListing(w, strings.TrimPrefix(name, "v"))
+	case strings.HasPrefix(dir, "v") && !strings.Contains(dir, "/") && name == manager.ChecksumsFileName:
+		s.serveChecksums(w, r, strings.TrimPrefix(dir, "v"))
+	case strings.HasPrefix(dir, "v") && !strings.Contains(dir, "/"):
+		s.serveArchive(w, r, strings.TrimPrefix(dir, "v"), name)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// loadIndex devuelve el contenido de index.json de la caché, actualizándolo si corresponde
+func (s *mirrorServer) loadIndex() ([]byte, error) {
+	if s.pullThrough {
+		if _, err := s.manager.Index(); err != nil {
+			return nil, err
+		}
+	}
+	return os.ReadFile(manager.IndexCachePath())
+}
+
+func (s *mirrorServer) serveIndexJSON(w http.ResponseWriter, r *http.Request) {
+	body, err := s.loadIndex()
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// serveIndexTab genera index.tab a partir de index.json, con las mismas columnas que nodejs.org
+func (s *mirrorServer) serveIndexTab(w http.ResponseWriter) {
+	body, err := s.loadIndex()
+	if err != nil {
+		http.Error(w, i18n.T("serve.index_unavailable"), http.StatusNotFound)
+		return
+	}
+
+	var entries []map[string]interface{}
+	if err := json.Unmarshal(body, &entries); err != nil {
+		http.Error(w, i18n.T("serve.index_invalid"), http.StatusInternalServerError)
+		return
+	}
+
+	columns := []string{"version", "date", "files", "npm", "v8", "uv", "zlib", "openssl", "modules", "lts", "security"}
+	var sb strings.Builder
+	sb.WriteString(strings.Join(columns, "\t") + "\n")
+	for _, entry := range entries {
+		values := make([]string, len(columns))
+		for i, column := range columns {
+			values[i] = indexTabValue(entry[column])
+		}
+		sb.WriteString(strings.Join(values, "\t") + "\n")
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write([]byte(sb.String()))
+}
+
+func indexTabValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "-"
+	case bool:
+		if v {
+			return "true"
+		}
+		return "-"
+	case string:
+		if v == "" {
+			return "-"
+		}
+		return v
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = fmt.Sprint(item)
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(value)
+}
+
+func (s *mirrorServer) serveChecksums(w http.ResponseWriter, r *http.Request, version string) {
+	if _, err := os.Stat(manager.ChecksumsCachePath(version)); err != nil && s.pullThrough {
+		s.manager.Checksums(version)
+	}
+	http.ServeFile(w, r, manager.ChecksumsCachePath(version))
+}
+
+func (s *mirrorServer) serveArchive(w http.ResponseWriter, r *http.Request, version, name string) {
+	expectedSHA := ""
+	if checksums, err := s.checksums(version); err == nil {
+		expectedSHA = checksums[name]
+	}
+
+	// En modo pull-through sólo se piden al mirror los archivos publicados en SHASUMS256.txt,
+	// lo que además limita las entradas de s.locks
+	if s.pullThrough && expectedSHA == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	archivePath, cached := manager.FindCachedArchive(name, expectedSHA)
+	if !cached && s.pullThrough {
+		// Evitar descargar el mismo archivo varias veces si llegan solicitudes simultáneas
+		lock, _ := s.locks.LoadOrStore(name, &sync.Mutex{})
+		lock.(*sync.Mutex).Lock()
+		archivePath, cached = manager.FindCachedArchive(name, expectedSHA)
+		if !cached {
+			var err error
+			archivePath, err = s.manager.DownloadArchive(version, name, expectedSHA)
+			cached = err == nil
+		}
+		lock.(*sync.Mutex).Unlock()
+	}
+
+	if !cached {
+		http.NotFound(w, r)
+		return
+	}
+
+	file, err := os.Open(archivePath)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.ServeContent(w, r, name, info.ModTime(), file)
+}
+
+func (s *mirrorServer) checksums(version string) (map[string]string, error) {
+	if s.pullThrough {
+		return s.manager.Checksums(version)
+	}
+	file, err := os.Open(manager.ChecksumsCachePath(version))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return manager.ParseChecksums(file), nil
+}
+
+// serveRootListing muestra las versiones que tienen archivos en la caché
+func (s *mirrorServer) serveRootListing(w http.ResponseWriter) {
+	archives, _ := manager.ListCachedArchives()
+	versions := map[string]bool{}
+	for _, archive := range archives {
+		if match := versionDirPattern.FindStringSubmatch(trimArchiveExtension(archive.Name)); match != nil {
+			versions[match[1]] = true
+		}
+	}
+
+	links := []string{"index.json", "index.tab"}
+	var sorted []string
+	for version := range versions {
+		sorted = append(sorted, "v"+version+"/")
+	}
+	sort.Strings(sorted)
+	writeListing(w, "/", append(links, sorted...))
+}
+
+// serveVersionListing muestra los archivos en caché de una versión
+func (s *mirrorServer) serveVersionListing(w http.ResponseWriter, version string) {
+	archives, _ := manager.ListCachedArchives()
+	var links []string
+	if _, err := os.Stat(manager.ChecksumsCachePath(version)); err == nil {
+		links = append(links, manager.ChecksumsFileName)
+	}
+	for _, archive := range archives {
+		if strings.HasPrefix(archive.Name, "node-v"+version+"-") {
+			links = append(links, archive.Name)
+		}
+	}
+	sort.Strings(links)
+	writeListing(w, "/v"+version+"/", links)
+}
+
+func writeListing(w http.ResponseWriter, title string, links []string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintf(w, "<html><head><title>Index of %s</title></head><body><h1>Index of %s</h1><hr><pre>\n", html.EscapeString(title), html.EscapeString(title))
+	for _, link := range links {
+		fmt.Fprintf(w, "<a href=\"%s\">%s</a>\n", html.EscapeString(link), html.EscapeString(link))
+	}
+	fmt.Fprint(w, "</pre><hr></body></html>\n")
+}
+
+func trimArchiveExtension(name string) string {
+	for _, extension := range []string{".zip", ".tar.gz", ".tar.xz", ".tgz"} {
+		if strings.HasSuffix(name, extension) {
+			return strings.TrimSuffix(name, extension)
+		}
+	}
+	return name
+}
+
+// statusRecorder guarda el código de respuesta para el registro de solicitudes
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
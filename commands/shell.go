@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"polynode/i18n"
+	"polynode/pkg/manager"
 	"polynode/shared"
 	"runtime"
 )
@@ -12,7 +14,7 @@ func OpenShell() error {
 	// Obtener la versión actual de Node.js
 	currentVersion := shared.GetCurrentVersion()
 	if currentVersion == "" {
-		return fmt.Errorf("no hay ninguna versión seleccionada. Utilice el comando 'use' para seleccionar una versión")
+		return i18n.Errorf("shell.no_version")
 	}
 
 	// Obtener la ruta del directorio actual de Node.js
@@ -20,14 +22,22 @@ func OpenShell() error {
 	
 	// Verificar que el directorio existe
 	if _, err := os.Stat(currentVersionPath); os.IsNotExist(err) {
-		return fmt.Errorf("la versión actual no está instalada correctamente")
+		return i18n.Errorf("shell.not_installed")
 	}
 
 	// Configurar las variables de entorno
 	env := os.Environ()
 	
-	// Agregar el directorio de Node.js al PATH
-	nodePath := currentVersionPath
+	// Registrar el uso de la versión, que tiene en cuenta "poly prune --unused"
+	if err := manager.RecordUse(currentVersion); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+
+	// Habilitar el gestor de paquetes del proyecto (campo packageManager del package.json)
+	ensureProjectPackageManager(currentVersion)
+
+	// Agregar el directorio de los ejecutables de Node.js (y de los shims de Corepack) al PATH
+	nodePath := shared.GetBinPath(currentVersionPath)
 	pathEnv := os.Getenv("PATH")
 	
 	// En Windows, el PATH se separa con punto y coma
@@ -67,9 +77,9 @@ func OpenShell() error {
 		shellCmd.Stderr = os.Stderr
 	}
 
-	fmt.Printf("Abriendo shell con Node.js v%s...\n", currentVersion)
-	fmt.Printf("Directorio de Node.js: %s\n", currentVersionPath)
-	fmt.Println("Presiona Ctrl+C para salir del shell")
+	fmt.Print(i18n.T("shell.opening", currentVersion))
+	fmt.Print(i18n.T("shell.node_dir", currentVersionPath))
+	fmt.Println(i18n.T("shell.exit_hint"))
 
 	// Ejecutar el shell
 	return shellCmd.Run()
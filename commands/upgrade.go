@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"polynode/i18n"
+	"polynode/pkg/manager"
+	"polynode/shared"
+)
+
+/*
+ExecuteUpgrade actualiza una versión instalada (por defecto, la actual) a la versión más nueva
+de su línea según index.json: la misma versión mayor o, con --patch-only, la misma versión menor.
+Instala la versión nueva con los paquetes globales de la anterior, la selecciona si la anterior
+era la actual, actualiza los alias que apuntaban a la anterior y, con --prune, la desinstala.
+*/
+func ExecuteUpgrade(args []string) error {
+	patchOnly := false
+	prune := false
+	var specs []string
+	for _, arg := range args {
+		switch arg {
+		case "--patch-only":
+			patchOnly = true
+		case "--prune":
+			prune = true
+		default:
+			specs = append(specs, arg)
+		}
+	}
+	if len(specs) > 1 {
+		return usageError("cli.command_usage", placeholders("upgrade [versión] [--patch-only] [--prune]"))
+	}
+
+	current := shared.GetCurrentVersion()
+	from := current
+	if len(specs) == 1 {
+		version, err := manager.ResolveInstalled(specs[0])
+		if err != nil {
+			return err
+		}
+		from = version
+	}
+	if from == "" {
+		return i18n.Errorf("shell.no_version")
+	}
+
+	m := newManager(newConsoleReporter(false))
+	to, err := m.LatestInLine(from, patchOnly)
+	if err != nil {
+		return err
+	}
+	if toVersion, err := manager.ParseVersion(to); err == nil {
+		if fromVersion, err := manager.ParseVersion(from); err == nil && manager.CompareVersions(toVersion, fromVersion) <= 0 {
+			printInfo("upgrade.up_to_date", from)
+			return nil
+		}
+	}
+
+	printInfo("upgrade.upgrading", from, to)
+	options := installOptions{PackagesFrom: from}
+	if isInstalled(to) {
+		printInfo("upgrade.already_installed", to)
+		if err := setupGlobalPackages(to, options); err != nil {
+			return err
+		}
+	} else if err := InstallVersions([]string{to}, options); err != nil {
+		return err
+	}
+
+	// Los paquetes globales se copian antes de cambiar de versión, ya que con link_mode copy están en current
+	if from == current {
+		if _, err := UseNodeVersion(to); err != nil {
+			return err
+		}
+		printInfo("use.changed", to)
+	}
+
+	changes, err := manager.RetargetAliases(from, to)
+	if err != nil {
+		return err
+	}
+	for _, change := range changes {
+		printInfo("alias.refreshed", change.Name, change.Target, change.Previous, change.Version)
+	}
+
+	if prune {
+		if err := UninstallNodeVersion(from); err != nil {
+			return i18n.Errorf("uninstall.failed", err)
+		}
+	}
+
+	printInfo("upgrade.done", from, to)
+	return nil
+}
@@ -2,18 +2,34 @@ package commands
 
 import (
 	"fmt"
-	"polynode/shared"
-	"strings"
+	"polynode/i18n"
 )
 
-func ShowCurrentNodeVersion() {
+// versionOutput es la salida estructurada de "poly version"; Version es null si no hay ninguna seleccionada
+type versionOutput struct {
+	Version *string `json:"version"`
+}
+
+func ShowCurrentNodeVersion() error {
 	// Obtener versión actual
-	currentVersion := shared.GetCurrentVersion()
+	currentVersion, err := newManager(nil).Current()
+	if err != nil {
+		return err
+	}
+
+	if StructuredOutput() {
+		output := versionOutput{}
+		if currentVersion != "" {
+			output.Version = &currentVersion
+		}
+		return writeOutput(output)
+	}
 
 	if currentVersion == "" {
-		fmt.Println("No hay ninguna versión seleccionada. Utilice el comando use para seleccionar una.")
-		return
+		fmt.Println(i18n.T("version.none_selected"))
+		return nil
 	}
 
-	fmt.Println(strings.TrimSpace(string(currentVersion)))
+	fmt.Println(currentVersion)
+	return nil
 }
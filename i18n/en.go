@@ -0,0 +1,637 @@
+package i18n
+
+// en es el catálogo de mensajes en inglés
+var en = map[string]string{
+	// Configuración
+	"config.key.mirror":                "Base URL of the Node versions repository",
+	"config.key.http_proxy":            "URL of the HTTP proxy used for downloads",
+	"config.key.https_proxy":           "URL of the proxy used for HTTPS downloads (defaults to http_proxy)",
+	"config.key.no_proxy":              "Comma-separated list of hosts, domains or networks that bypass the proxy",
+	"config.key.proxy_pac":             "URL or path of the proxy auto-configuration (PAC) file",
+	"config.key.ca_file":               "PEM files with additional root certificates, separated by the PATH separator",
+	"config.key.client_cert":           "Client certificate (PEM) for mutual TLS with the mirror",
+	"config.key.client_key":            "Private key (PEM) of the client certificate",
+	"config.key.tls_min_version":       "Minimum TLS version (1.0, 1.1, 1.2, 1.3)",
+	"config.key.insecure":              "Disable TLS certificate verification (for troubleshooting only)",
+	"config.key.parallel_downloads":    "Maximum number of simultaneous downloads in 'install'",
+	"config.key.arch":                  "Default architecture of downloaded versions (x64, x86, arm64)",
+	"config.key.cache_ttl":             "How long the downloaded version index stays valid",
+	"config.key.cache_max_size":        "Maximum size of the download cache (0 for no limit)",
+	"config.key.timeout":               "Maximum time to wait when connecting to the server",
+	"config.key.lang":                  "Language of the messages (es, en; defaults to the system language)",
+	"config.key.auto_install":          "Automatically install the version given to 'use' if it is not installed",
+	"config.key.backup_dir":            "Backup directory (defaults to the workspace)",
+	"config.key.backup_format":         "Backup format (zip, tar.gz, tar.zst)",
+	"config.key.link_mode":             "How the current version is activated (copy, symlink)",
+	"config.key.default_packages":      "File with the global npm packages installed in every new version (defaults to default-packages in the workspace)",
+	"config.key.schedule_url":          "URL of the Node release schedule (schedule.json)",
+	"config.read_error":                "Error reading the configuration file %s: %w",
+	"config.decode_error":              "Error decoding the configuration file %s: %w",
+	"config.unsupported_version":       "The configuration file %s uses version %d, which this version of polynode does not support",
+	"config.serialize_error":           "Error serializing the configuration: %w",
+	"config.write_error":               "Error writing the configuration file: %w",
+	"config.legacy_open_error":         "Error opening the file %s: %w",
+	"config.legacy_decode_error":       "Error decoding the file %s: %w",
+	"config.legacy_rename_error":       "Error renaming the file %s: %w",
+	"config.unknown_key":               "Unknown configuration key: %s",
+	"config.invalid_value":             "Invalid value for %s: %w",
+	"config.invalid_duration":          "invalid duration: %s",
+	"config.invalid_size":              "invalid size: %s",
+	"config.expected_positive_int":     "expected an integer greater than zero",
+	"config.expected_bool":             "expected true or false",
+	"config.expected_url":              "expected an http:// or https:// URL",
+	"config.expected_one_of":           "expected one of: %s",
+	"config.legacy_migrated":           "Migrated the configuration from %s to %s\n",
+	"config.env_invalid_ignored":       "Ignoring invalid value of %s: %s",
+	"config.file_invalid_ignored":      "Ignoring invalid value of %s in %s: %s",
+	"config.project_sensitive_ignored": "Ignoring %s in %s: network and security options are only accepted in the user configuration",
+	"config.invalid_workspace":         "Invalid workspace: %v",
+	"config.updated":                   "Configuration updated: %s = %s\n",
+	"config.removed":                   "Removed the configuration of %s\n",
+	"config.file":                      "Configuration file: %s\n",
+	"config.project_file":              "Project configuration: %s\n",
+
+	// Credenciales
+	"credentials.read_error":      "Error reading the credentials file: %w",
+	"credentials.decode_error":    "Error decoding the credentials file: %w",
+	"credentials.remove_error":    "Error removing the credentials file: %w",
+	"credentials.serialize_error": "Error serializing the credentials: %w",
+	"credentials.write_error":     "Error writing the credentials file: %w",
+
+	// Node
+	"node.version_error": "Error getting the Node.js version: %v",
+
+	// Archivos
+	"archive.invalid_path":       "The archive contains an invalid path: %s",
+	"archive.invalid_link":       "The archive contains a symbolic link pointing outside the destination directory: %s -> %s",
+	"archive.symlink_in_path":    "The archive contains a path that goes through a symbolic link: %s",
+	"archive.unsupported_format": "Unsupported archive format: %s",
+	"archive.tar_error":          "Error running tar: %v %s",
+
+	// Caché de descargas
+	"cache.read_error":        "Error reading the download cache: %v",
+	"cache.mkdir_error":       "Error creating the cache directory: %v",
+	"cache.create_file_error": "Error creating the file %s: %v",
+	"cache.save_file_error":   "Error saving the file %s: %v",
+	"cache.sha_mismatch":      "The SHA-256 of %s does not match %s (expected %s, got %s)",
+	"cache.move_error":        "Error moving %s into the cache: %v",
+	"cache.remove_error":      "Error removing %s from the cache: %v",
+
+	// Checksums
+	"checksums.get_error":        "Error getting %s: %v",
+	"checksums.get_status_error": "Error getting %s: %s",
+	"checksums.read_error":       "Error reading %s: %v",
+	"checksums.save_error":       "Error saving %s to the cache: %v",
+	"checksums.missing_file":     "%s does not include the SHA-256 of %s; the archive cannot be verified",
+
+	// Red
+	"http.invalid_proxy_url": "Error parsing the proxy URL %s: %v",
+	"http.client_error":      "Could not process the network configuration (proxy or TLS)",
+	"http.insecure_warning":  "WARNING: TLS certificate verification is DISABLED.\nDownloads can be intercepted or modified by third parties.\nOnly use the insecure option for troubleshooting and disable it with:\n  poly config unset insecure",
+	"http.pac_detected":      "Proxy auto-configuration (PAC) detected",
+	"http.proxy_detected":    "Proxy configuration detected",
+
+	// Índice de versiones
+	"index.decode_error":      "Error decoding the contents of index.json: %v",
+	"index.expired":           "The saved version index has expired",
+	"http.request_error":      "Error creating the HTTP request: %v",
+	"index.request_error":     "Error making the HTTP request for information about the available versions: %v",
+	"index.status_error":      "Error getting information about the available versions: %s",
+	"index.read_error":        "Error reading the contents of index.json: %v",
+	"index.no_match":          "No version matching %s was found",
+	"index.no_lts":            "Could not determine the LTS version",
+	"index.stale_fallback":    "Could not get the version index from the mirror, using the saved index",
+	"schedule.decode_error":   "Error decoding the contents of schedule.json: %v",
+	"schedule.request_error":  "Error making the HTTP request for the release schedule: %v",
+	"schedule.status_error":   "Error getting the release schedule: %s",
+	"schedule.read_error":     "Error reading the contents of schedule.json: %v",
+	"schedule.stale_fallback": "Could not get the release schedule, using the saved schedule",
+
+	// Instalación
+	"install.failed_count":      "Could not install %d of %d versions",
+	"install.mkdir_error":       "Error creating the installation directory: %v",
+	"install.extract_error":     "Error extracting the archive: %v",
+	"install.download_error":    "Error getting the file %s: %v",
+	"install.version_not_found": "The specified version could not be found: %s",
+
+	// Versiones instaladas
+	"list.read_error":        "Error reading the installation folder: %v",
+	"list.not_installed":     "Version %s is not installed",
+	"version.invalid_format": "Invalid version format: %s",
+	"version.invalid_major":  "Error converting the major part: %v",
+	"version.invalid_minor":  "Error converting the minor part: %v",
+	"version.invalid_patch":  "Error converting the patch part: %v",
+
+	// Proxy
+	"pac.download_error":        "Error downloading the PAC file %s: %v",
+	"pac.download_status_error": "Error downloading the PAC file %s: %s",
+	"pac.read_error":            "Error reading the PAC file %s: %v",
+	"pac.invalid_url":           "Invalid PAC file URL: %v",
+	"pac.unsupported_result":    "The PAC file did not return a supported proxy: %s",
+	"proxy.config_error":        "Error configuring the proxy: %w",
+	"proxy.https_updated":       "Configuration updated to use the HTTPS proxy '%s'\n",
+	"proxy.bypass_updated":      "Updated the list of hosts that do not use the proxy: %s\n",
+	"proxy.pac_updated":         "Configuration updated to use the PAC file '%s'\n",
+	"proxy.save_error":          "Error saving the proxy configuration: %w",
+	"proxy.updated":             "Configuration updated to use the proxy '%s'\n",
+	"proxy.missing_user":        "The proxy user name is required",
+	"proxy.password_prompt":     "Proxy password for %s: ",
+	"proxy.password_error":      "Error reading the password: %w",
+	"proxy.password_echo_error": "Cannot hide the password in this terminal (%v); provide it in the %s environment variable or through standard input",
+	"proxy.credentials_saved":   "Proxy credentials saved in %s\n",
+	"proxy.cleared":             "Proxy configuration removed",
+	"proxy.none":                "No proxy is configured in polynode; the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are used",
+
+	// TLS
+	"tls.ca_read_error":          "Error reading the certificate file %s: %v",
+	"tls.ca_invalid":             "The file %s does not contain valid PEM certificates",
+	"tls.client_cert_incomplete": "Both client_cert and client_key must be set to use a client certificate",
+	"tls.client_cert_error":      "Error loading the client certificate: %v",
+
+	// Desinstalación
+	"uninstall.not_installed": "Version %s is not installed",
+	"uninstall.error":         "Error uninstalling version %s: %w",
+	"uninstall.current_error": "Error uninstalling the current version: %w",
+
+	// Selección de versión
+	"use.not_installed":        "The specified Node version is not installed: %s",
+	"use.auto_install":         "Version %s is not installed, it will be installed automatically",
+	"use.already_selected":     "Version %s is already selected, no need to switch",
+	"use.remove_link_error":    "Error removing the current link: %v",
+	"use.move_previous_error":  "Error moving the previous version: %v",
+	"use.symlink_error":        "Error creating the symbolic link: %v",
+	"use.copy_error":           "Error copying the files: %v",
+	"use.rename_current_error": "Error renaming current: %v",
+
+	// Línea de comandos
+	"global.flag.workspace":    "Workspace to use (defaults to POLYNODE_PATH)",
+	"global.flag.output":       "Output format for the commands that support it",
+	"global.flag.json":         "Same as --output json",
+	"global.flag.quiet":        "Only show errors and warnings",
+	"global.flag.verbose":      "Show additional troubleshooting information",
+	"global.flag.no-color":     "Do not use colors or terminal control sequences",
+	"global.flag.yes":          "Answer yes to all confirmations",
+	"global.flag.help":         "Show help for the command",
+	"cli.invalid_workspace":    "Invalid workspace: %v",
+	"cli.workspace_error":      "Error creating the installation folder: %v",
+	"cli.debug.workspace":      "Workspace: %s",
+	"cli.debug.config":         "Configuration: %s",
+	"cli.debug.project_config": "Project configuration: %s",
+	"cli.debug.mirror":         "Mirror: %s (%s)",
+	"cli.debug.language":       "Language: %s",
+	"cli.debug.exit_code":      "Exit code: %d (%s)",
+	"cli.missing_flag_value":   "Missing value for flag %s",
+	"cli.unknown_option":       "Unknown option: %s%s",
+	"cli.unknown_command":      "Unknown command: %s%s",
+	"cli.unknown_subcommand":   "Unknown %s subcommand: %s%s",
+	"cli.subcommand_usage":     "Usage: poly %s <%s>",
+	"cli.did_you_mean":         "\nDid you mean \"%s\"?",
+	"cli.see_help":             "\nRun \"poly help\" to see the available commands and options.",
+	"cli.command_usage":        "Usage: poly %s",
+	"cli.invalid_flag_value":   "Invalid value for %s: %s",
+
+	// Ayuda
+	"help.usage":                  "Usage: poly %s",
+	"help.subcommand_placeholder": "<subcommand>",
+	"help.options_placeholder":    "[options]",
+	"help.subcommands":            "Subcommands:",
+	"help.options":                "Options:",
+	"help.structured":             "Supports --output json|yaml to produce output for scripts.",
+	"help.global_options_hint":    "Global options: %s (see poly help)",
+	"help.root_usage":             "Usage: poly <command> [parameters]",
+	"help.commands":               "Commands",
+	"help.global_options":         "Global options",
+	"help.exit_codes":             "Exit codes",
+	"help.exit.ok":                "Success",
+	"help.exit.error":             "General error",
+	"help.exit.usage":             "Invalid command, subcommand or option",
+	"help.exit.not_found":         "The version does not exist in the mirror or is not installed",
+	"help.exit.network":           "Network error accessing the mirror",
+	"help.exit.integrity":         "A file does not match the expected SHA-256",
+	"help.exit.eol":               "An installed version reached end of life (outdated --fail-on-eol)",
+	"help.exit.vulnerable":        "An installed version has known vulnerabilities (audit)",
+	"help.command_help_hint":      "Run \"poly <command> --help\" to see the help for a command.",
+
+	// Parámetros de la ayuda
+	"arg.directorio":  "directory",
+	"arg.archivo":     "file",
+	"arg.archivo.tar": "file.tar",
+	"arg.clave":       "key",
+	"arg.valor":       "value",
+	"arg.comando":     "command",
+	"arg.destino":     "destination",
+	"arg.formato":     "format",
+	"arg.duración":    "duration",
+	"arg.dirección":   "address",
+	"arg.usuario":     "user",
+	"arg.versión":     "version",
+	"arg.contraseña":  "password",
+	"arg.nombre":      "name",
+	"arg.origen":      "source",
+	"arg.gestor":      "manager",
+
+	// Comandos
+	"cmd.install.summary":                      "Install one or more node versions into the local repository (20, 20.11, 20.11.0 or lts)",
+	"cmd.use.summary":                          "Use a previously installed node version (without parameters, the default alias)",
+	"cmd.list.summary":                         "List the node versions installed in the local repository",
+	"cmd.version.summary":                      "Show the selected Node version",
+	"cmd.ls-remote.summary":                    "List the versions published in the mirror",
+	"cmd.info.summary":                         "Show the components, platforms and installation details of a version",
+	"cmd.info.description":                     "Includes the npm, V8, libuv, zlib and OpenSSL versions and the native module ABI (modules), and\nshows whether the ABI matches the current version.",
+	"cmd.uninstall.summary":                    "Remove the given node version from the local repository",
+	"cmd.outdated.summary":                     "Show installed versions with newer releases or without support",
+	"cmd.outdated.description":                 "The status of each line (current, active LTS, maintenance or end of life) and the end of support date\ncome from the Node release schedule (schedule_url).",
+	"cmd.outdated.flag.fail-on-eol":            "Exit with code 6 if any version reached its end of life",
+	"cmd.audit.summary":                        "Find installed versions with known security vulnerabilities",
+	"cmd.audit.description":                    "A version is vulnerable if a security release of the same line was published after it (security\nfield of index.json). If there is any, the command exits with code 7.",
+	"cmd.upgrade.summary":                      "Upgrade a version to the newest one in its line",
+	"cmd.upgrade.description":                  "Without parameters the current version is upgraded. The new version is installed with the global\nnpm packages of the old one, it is selected if the old one was the current version, and the aliases\npointing to the old one are moved to the new one.",
+	"cmd.upgrade.flag.patch-only":              "Only look within the same minor version (20.11.x)",
+	"cmd.upgrade.flag.prune":                   "Uninstall the old version",
+	"cmd.prune.summary":                        "Uninstall old or unused versions",
+	"cmd.prune.description":                    "If both --keep and --unused are given, the versions matching both conditions are removed. The current\nversion and the versions with an alias are never removed. The last use date is saved by poly use and\npoly shell; if a version was never used, its installation date is taken.",
+	"cmd.prune.flag.keep":                      "Keep the n newest versions of each major version",
+	"cmd.prune.flag.unused":                    "Remove the versions not used within that time (for example 90d)",
+	"cmd.prune.flag.dry-run":                   "Show which versions would be removed without removing them",
+	"cmd.proxy.summary":                        "Use the given proxy url to download Node versions",
+	"cmd.config.summary":                       "Show or change the polynode configuration",
+	"cmd.config.list.summary":                  "Show every key with its value and source",
+	"cmd.config.get.summary":                   "Show the value of a key",
+	"cmd.config.set.summary":                   "Save the value of a key in config.json",
+	"cmd.config.unset.summary":                 "Remove a key from config.json",
+	"cmd.bundle.summary":                       "Create or import bundles for offline installations",
+	"cmd.bundle.create.summary":                "Create a bundle for offline installations",
+	"cmd.bundle.import.summary":                "Import a bundle into the download cache",
+	"cmd.cache.summary":                        "Manage the download cache",
+	"cmd.cache.list.summary":                   "List the files in the download cache",
+	"cmd.cache.clean.summary":                  "Clean the download cache",
+	"cmd.serve.summary":                        "Serve the download cache as a Node mirror for the local network",
+	"cmd.check.summary":                        "Check the polynode installation setup",
+	"cmd.backup.summary":                       "Back up the repository and the current version",
+	"cmd.backup.list.summary":                  "List the backups of the workspace",
+	"cmd.restore.summary":                      "Restore a backup (saves the current state first)",
+	"cmd.export.summary":                       "Export the environment (versions, configuration and global packages) to a JSON manifest",
+	"cmd.import.summary":                       "Recreate the environment described in a manifest",
+	"cmd.shell.summary":                        "Open a shell with the current Node.js version in the PATH",
+	"cmd.help.summary":                         "Show this help or the help for a command",
+	"cmd.install.description":                  "Partial versions are resolved with the mirror index. With --from the installation is done offline\nfrom an archive (.zip, .tar.gz or .tar.xz) or a local directory. The global packages listed in the\ndefault_packages file are installed in every new version.",
+	"cmd.restore.description":                  "With --yes the confirmation is skipped.",
+	"cmd.install.flag.from":                    "Install offline from a local archive or directory",
+	"cmd.install.flag.shasums":                 "Verify the --from archive against a local SHASUMS256.txt",
+	"cmd.install.flag.reinstall-packages-from": "Also install the global npm packages of another installed version",
+	"cmd.install.flag.exact":                   "With --reinstall-packages-from, keep the package versions",
+	"cmd.ls-remote.flag.lts":                   "Only show LTS versions",
+	"cmd.proxy.flag.https":                     "Use a different proxy for HTTPS downloads",
+	"cmd.proxy.flag.bypass":                    "List of hosts, domains or networks that do not use the proxy",
+	"cmd.proxy.flag.pac":                       "Get the proxy for each download from a PAC file",
+	"cmd.proxy.flag.user":                      "Save the proxy credentials (prompts for the password without echoing it)",
+	"cmd.proxy.flag.clear":                     "Remove the proxy configuration",
+	"cmd.bundle.create.flag.versions":          "Versions to include",
+	"cmd.bundle.create.flag.platforms":         "Platforms to include (defaults to the current one)",
+	"cmd.cache.clean.flag.older-than":          "Only remove files unused for longer (for example 30d)",
+	"cmd.serve.flag.addr":                      "Address to listen on (defaults to :8080)",
+	"cmd.serve.flag.pull-through":              "Download the versions missing from the cache from the configured mirror",
+	"cmd.backup.flag.output":                   "Destination directory or file",
+	"cmd.backup.flag.format":                   "Backup format (zip, tar.gz, tar.zst)",
+	"cmd.backup.flag.level":                    "Compression level",
+	"cmd.backup.flag.keep":                     "Keep only the n most recent backups",
+	"cmd.import.flag.with-config":              "Also apply the settings in the manifest",
+	"cmd.import.flag.no-globals":               "Do not install the global npm packages",
+	"use.changed":                              "Switched to version v%s\n",
+	"use.security_warning":                     "Warning: there are security releases after Node v%s in its line (%s); upgrade with poly upgrade %s\n",
+	"uninstall.failed":                         "Error uninstalling the version: %w",
+	"shell.error":                              "Error opening the shell: %w",
+	"version.none_selected":                    "No version is selected. Use the use command to select one.",
+	"uninstall.current_removed":                "The current version has been uninstalled. Please select a new version with 'use'\n",
+	"uninstall.done":                           "Version %s was uninstalled successfully\n",
+	"list.empty":                               "There are no node versions installed in the local repository.",
+	"list.empty_hint":                          "Use poly install <version> to install one.",
+	"list.title":                               "Installed versions:",
+	"list.current_line":                        " - [%s] <- CURRENT",
+	"install.summary":                          "\nInstallation summary:\n",
+	"install.summary_error":                    " - %s: ERROR %v\n",
+	"install.summary_installed":                " - %s: installed\n",
+	"install.done":                             "Versions installed in %s\n",
+	"npm.read_globals_error":                   "Error reading the global packages of %s: %v",
+	"npm.not_found":                            "npm was not found in %s",
+	"npm.install_globals_error":                "Error installing the global packages: %v",
+	"cache.cleaned.one":                        "Removed %d file from the cache (%s freed)\n",
+	"cache.cleaned.other":                      "Removed %d files from the cache (%s freed)\n",
+	"cache.empty":                              "The download cache is empty.",
+	"cache.title":                              "Files in the download cache:",
+	"cache.total":                              "Total: %s (limit: %s)\n",
+	"lsremote.current_marker":                  " <- CURRENT",
+	"lsremote.installed_marker":                " [installed]",
+	"lsremote.empty":                           "No matching versions were found in the mirror.",
+	"lsremote.title":                           "Available versions:",
+	"check.where_error":                        "Error running 'where node': %w",
+	"check.where_start_error":                  "Error starting 'where node': %w",
+	"check.where_wait_error":                   "Error waiting for 'where node': %w",
+	"check.not_found":                          "No locations were found for the Node.js executable.",
+	"check.fix_hint":                           "Please update the system PATH with the following command:",
+	"check.mismatch":                           "The location of the Node.js executable does not match the expected location.",
+	"check.first_mismatch":                     "The first location of the Node.js executable does not match the expected location.",
+	"check.ok":                                 "The location of the Node.js executable matches the expected location.",
+	"check.first_ok":                           "The first location of the Node.js executable matches the expected location.",
+	"check.multiple":                           "Multiple locations of the Node executable were found. Removing the additional PATH entries of other Node installations is recommended to avoid conflicts.",
+	"serve.pull_through":                       "Versions missing from the cache will be downloaded from %s\n",
+	"serve.listening":                          "Serving the download cache at http://%s/\n",
+	"serve.stop_hint":                          "Press Ctrl+C to stop the server",
+	"serve.method_not_allowed":                 "method not allowed",
+	"serve.index_unavailable":                  "index.json not available",
+	"serve.index_invalid":                      "invalid index.json",
+	"shell.no_version":                         "no version is selected. Use the 'use' command to select one",
+	"shell.not_installed":                      "the current version is not installed correctly",
+	"shell.opening":                            "Opening a shell with Node.js v%s...\n",
+	"shell.node_dir":                           "Node.js directory: %s\n",
+	"shell.exit_hint":                          "Press Ctrl+C to exit the shell",
+	"npm.installing_globals.one":               "Installing %d global package in Node v%s...\n",
+	"npm.installing_globals.other":             "Installing %d global packages in Node v%s...\n",
+	"cmd.completion.summary":                   "Generate the completion script for bash, zsh, fish or PowerShell",
+	"cmd.completion.description":               "To enable it:\n  bash:       source <(poly completion bash)\n  zsh:        poly completion zsh > \"${fpath[1]}/_poly\"\n  fish:       poly completion fish > ~/.config/fish/completions/poly.fish\n  PowerShell: poly completion powershell | Out-String | Invoke-Expression",
+	"completion.unknown_shell":                 "Unsupported shell: %s (possible values: %s)",
+	"cmd.alias.summary":                        "Manage the names given to node versions",
+	"cmd.alias.description":                    "An alias can be used instead of a version (poly use work). The default alias is the version\nselected by poly use without parameters.",
+	"cmd.alias.set.summary":                    "Give a name to a version (18.19.0, 20 or lts)",
+	"cmd.alias.list.summary":                   "List the aliases and the version they point to",
+	"cmd.alias.rm.summary":                     "Remove an alias",
+	"cmd.alias.refresh.summary":                "Resolve the aliases of version lines (lts, 20) again with the mirror index",
+	"npm.default_packages_error":               "Error reading the default packages file %s: %v",
+	"npm.nothing_to_migrate":                   "Node v%s has no global packages missing from Node v%s\n",
+	"npm.migrating.one":                        "Migrating %d global package from Node v%s to Node v%s...\n",
+	"npm.migrating.other":                      "Migrating %d global packages from Node v%s to Node v%s...\n",
+	"npm.migrated":                             "The global packages were installed in Node v%s\n",
+	"cmd.migrate-globals.summary":              "Install the global npm packages of one version into another",
+	"cmd.migrate-globals.description":          "The latest versions of the packages are installed, unless --exact is given.",
+	"cmd.migrate-globals.flag.exact":           "Keep the package versions",
+	"cmd.pm.summary":                           "Manage package managers (pnpm, Yarn) with Corepack",
+	"cmd.pm.description":                       "The pnpm and yarn shims are created in the Node version directory, so they are available in the PATH\nof the current version and in poly shell without installing them as global packages. Without parameters,\npoly pm use uses the packageManager field of the project package.json.",
+	"cmd.pm.use.summary":                       "Enable a package manager and activate the given version",
+	"cmd.pm.use.flag.node":                     "Node version to enable it in (defaults to the current one)",
+	"cmd.pm.disable.summary":                   "Remove the package manager shims",
+	"cmd.pm.disable.flag.node":                 "Node version to remove them from (defaults to the current one)",
+
+	// Salida
+	"output.invalid_format": "Invalid output format: %s (possible values: json, yaml, text)",
+	"output.encode_error":   "Error generating the output: %v",
+	"output.unsupported":    "The %s command does not support --output json|yaml",
+
+	// Copias de seguridad
+	"backup.created":               "Backup created at: %s\n",
+	"backup.pruned":                "Removed the old backup %s\n",
+	"backup.unsupported_format":    "Unsupported backup format: %s (zip, tar.gz, tar.zst)",
+	"backup.inside_workspace":      "The backup cannot be saved inside %s",
+	"backup.destination_error":     "error creating the destination directory: %w",
+	"backup.create_error":          "error creating the backup file: %w",
+	"backup.add_error":             "error adding files from '%s' to the backup: %w",
+	"backup.write_error":           "error writing the backup: %w",
+	"backup.read_dir_error":        "error reading the backup directory: %w",
+	"backup.remove_error":          "error removing the backup %s: %w",
+	"backup.list_empty":            "There are no backups in %s.\n",
+	"backup.list_empty_hint":       "Use poly backup to create one.",
+	"backup.list_title":            "Backups:",
+	"backup.zstd_error":            "Could not run zstd (required for the tar.zst format): %v",
+	"backup.invalid_level":         "The compression level for %s must be between 1 and %d",
+	"backup.command_error":         "Error running %s: %v",
+	"backup.zstd_decompress_error": "Error decompressing with zstd: %v",
+	"backup.unsupported_file":      "Unsupported backup format: %s",
+
+	// Paquetes sin conexión
+	"bundle.created.one":      "Created bundle %s with %d file (%s)\n",
+	"bundle.created.other":    "Created bundle %s with %d files (%s)\n",
+	"bundle.imported.one":     "Imported %d file into the download cache\n",
+	"bundle.imported.other":   "Imported %d files into the download cache\n",
+	"bundle.index_error":      "Could not get the version index: %v",
+	"bundle.create_error":     "Error creating the file %s: %v",
+	"bundle.missing_platform": "Version %s has no published file for platform %s",
+	"bundle.using_cache":      "Using %s from the download cache\n",
+	"bundle.manifest_error":   "Error serializing the bundle manifest: %v",
+	"bundle.open_error":       "Error opening the bundle %s: %v",
+	"bundle.read_error":       "Error reading the bundle %s: %v",
+	"bundle.save_index_error": "Error saving the version index: %v",
+	"bundle.imported_file":    "Imported %s\n",
+	"bundle.write_error":      "Error writing %s to the bundle: %v",
+
+	// bundle
+	"bundle.invalid_version":   "Invalid version in bundle entry %s: %v",
+	"bundle.missing_checksums": "Bundle entry %s appears before its %s file",
+	"bundle.unlisted_file":     "Bundle entry %s is not listed in its %s file",
+
+	// Manifiestos de entorno
+	"lock.exported.one":        "Exported the environment to %s (%d version)\n",
+	"lock.exported.other":      "Exported the environment to %s (%d versions)\n",
+	"lock.encode_error":        "Error serializing the environment manifest: %v",
+	"lock.save_error":          "Error saving %s: %v",
+	"lock.no_checksum":         "Could not get the SHA-256 of %s; the version will be exported without verification\n",
+	"lock.credentials_removed": "The user and password were removed from the %s setting in the manifest\n",
+	"lock.read_error":          "Error reading %s: %v",
+	"lock.invalid":             "The file %s is not a valid environment manifest: %v",
+	"lock.unsupported_version": "Unsupported manifest version: %d",
+	"lock.config_ignored":      "Ignoring the configuration %s: %v\n",
+	"lock.config_set":          "Configuration %s = %s\n",
+	"lock.config_skipped":      "The manifest includes settings that were not applied; use --with-config to apply them\n",
+	"lock.sensitive_config":    "The manifest changes network and security settings:\n",
+	"lock.sensitive_confirm":   "Apply these settings?",
+	"lock.sensitive_skipped":   "The network and security settings were not applied\n",
+	"lock.other_platform":      "The manifest is for platform %s; the versions for %s will be installed without checking the manifest SHA-256\n",
+	"lock.current":             "Current version: %s\n",
+	"lock.imported":            "Imported the environment from %s\n",
+	"lock.already_installed":   "Version %s is already installed\n",
+	"lock.checksum_mismatch":   "The SHA-256 of %s in the mirror (%s) does not match the manifest (%s)",
+	"lock.alias":               "Alias %s -> %s (%s)\n",
+
+	// Instalación sin conexión
+	"offline.usage":                "Usage: poly install <version> [<version> ...] | poly %s",
+	"offline.versions_with_from":   "Versions cannot be given together with --from",
+	"offline.access_error":         "Could not access %s: %v",
+	"offline.install_dir_error":    "Error creating the installation directory: %v",
+	"offline.shasums_ignored":      "--shasums is ignored when installing from a directory",
+	"offline.copying":              "Copying Node v%s from %s...\n",
+	"offline.copy_error":           "Error copying the files: %v",
+	"offline.installed":            "Node v%s installed in %s\n",
+	"offline.checksum_ok":          "The SHA-256 of %s matches %s\n",
+	"offline.temp_error":           "Error creating the temporary directory: %v",
+	"offline.extracting":           "Extracting files...",
+	"offline.extract_error":        "Error extracting the archive: %v",
+	"offline.move_error":           "Error moving the files to the repository: %v",
+	"offline.version_not_detected": "Could not detect the Node version in %s",
+	"offline.node_not_found":       "The Node executable for platform %s was not found in %s",
+	"offline.platform_mismatch":    "The archive platform (%s) does not match the current platform (%s)",
+	"offline.already_installed":    "Version %s is already installed",
+	"offline.shasums_open_error":   "Error opening %s: %v",
+	"offline.not_in_shasums":       "The file %s is not listed in %s",
+	"offline.checksum_error":       "Error computing the SHA-256 of %s: %v",
+	"offline.checksum_mismatch":    "The SHA-256 of %s does not match %s (expected %s, got %s)",
+	"offline.packages_with_from":   "--reinstall-packages-from cannot be given together with --from",
+
+	// Restauración
+	"restore.confirm":             "Do you want to continue?",
+	"confirm.prompt":              " [y/N]: ",
+	"restore.summary.one":         "Backup: %s (%d file, %s)\n",
+	"restore.summary.other":       "Backup: %s (%d files, %s)\n",
+	"restore.open_error":          "Could not open the backup %s: %v",
+	"restore.verifying":           "Verifying the backup...",
+	"restore.invalid":             "The backup %s is not valid: %v",
+	"restore.cancelled":           "Restore cancelled",
+	"restore.safety_error":        "Could not back up the current state: %v",
+	"restore.safety_saved":        "The previous state was saved in: %s\n",
+	"restore.done":                "Restored the backup %s\n",
+	"restore.unexpected_file":     "contains an unexpected file: %s",
+	"restore.invalid_path":        "contains an invalid path: %s",
+	"restore.empty":               "contains no files",
+	"restore.no_manifest":         "The backup does not include a manifest; the file hashes will not be verified",
+	"restore.checksum_mismatch":   "the SHA-256 of %s does not match the manifest",
+	"restore.verified":            "The SHA-256 of every file was verified",
+	"restore.current_replaced":    " current:    the selected version will be replaced (%s)\n",
+	"restore.current_kept":        " current:    not in the backup, kept",
+	"restore.repository_replaced": " repository: the installed versions will be replaced (%s)\n",
+	"restore.repository_with":     "             with the ones in the backup (%s)\n",
+	"restore.repository_kept":     " repository: not in the backup, kept",
+	"restore.none":                "none",
+	"restore.move_error":          "Error moving %s: %v",
+	"restore.restore_error":       "Error restoring %s: %v",
+
+	// Progreso de las descargas
+	"progress.cached":          "cached",
+	"progress.extracting":      "extracting files...",
+	"progress.installed":       "installed",
+	"progress.downloading_url": "Downloading %s",
+	"progress.resolved":        "Version %s: %s\n",
+	"progress.downloading":     "Downloading %s...\n",
+
+	// Alias
+	"alias.invalid_name":         "Invalid alias name: %s (it must start with a letter and cannot be lts or current)",
+	"alias.not_found":            "The alias %s does not exist",
+	"alias.read_error":           "Error reading the alias file %s: %w",
+	"alias.decode_error":         "Error decoding the alias file %s: %w",
+	"alias.write_error":          "Error saving the alias file: %w",
+	"alias.set":                  "Alias %s -> %s set\n",
+	"alias.removed":              "Alias %s removed\n",
+	"alias.up_to_date":           "The aliases are up to date\n",
+	"alias.refreshed":            "Alias %s (%s) updated: %s -> %s\n",
+	"alias.empty":                "There are no aliases.",
+	"alias.empty_hint":           "Use poly alias set <name> <version> to create one.",
+	"alias.title":                "Aliases:",
+	"alias.not_installed_marker": " [not installed]",
+	"use.no_default":             "Usage: poly use <version>\nThere is no default version; set one with poly alias set default <version>.",
+
+	// Gestores de paquetes
+	"pm.no_project":      "no package manager was given and the project package.json has no packageManager field",
+	"pm.unknown":         "unsupported package manager: %s (options: %s)",
+	"pm.project":         "Project package manager: %s (%s)\n",
+	"pm.enabled":         "%s enabled in Node v%s\n",
+	"pm.disabled":        "Removed the %s shims from Node v%s\n",
+	"pm.no_corepack":     "Node v%s does not include Corepack (it is included since Node 16.9 and 14.19)",
+	"pm.corepack_error":  "error running Corepack: %w",
+	"pm.project_warning": "Warning: could not enable %s: %v\n",
+
+	// Actualización de versiones
+	"upgrade.up_to_date":        "Node v%s is already the newest version in its line\n",
+	"upgrade.upgrading":         "Upgrading Node v%s to v%s\n",
+	"upgrade.already_installed": "Node v%s is already installed\n",
+	"upgrade.done":              "Node v%s was upgraded to v%s\n",
+
+	// Versiones desactualizadas
+	"outdated.no_schedule":        "Could not get the Node release schedule: %v\n",
+	"outdated.eol_found.one":      "%d installed version reached its end of life",
+	"outdated.eol_found.other":    "%d installed versions reached their end of life",
+	"outdated.column.version":     "Version",
+	"outdated.column.latest":      "Latest",
+	"outdated.column.status":      "Status",
+	"outdated.column.eol":         "End of support",
+	"outdated.status.current":     "current",
+	"outdated.status.lts":         "active LTS",
+	"outdated.status.maintenance": "maintenance",
+	"outdated.status.eol":         "end of life",
+	"outdated.status.unknown":     "unknown",
+	"outdated.up_to_date":         "All installed versions have the latest release of their line",
+	"outdated.summary.one":        "%d version has a newer release (poly upgrade <version>)\n",
+	"outdated.summary.other":      "%d versions have a newer release (poly upgrade <version>)\n",
+	"outdated.eol_summary.one":    "%d version reached its end of life\n",
+	"outdated.eol_summary.other":  "%d versions reached their end of life\n",
+
+	// Auditoría de seguridad
+	"audit.vulnerable_found.one":   "%d installed version has known security vulnerabilities",
+	"audit.vulnerable_found.other": "%d installed versions have known security vulnerabilities",
+	"audit.title":                  "Security audit of the installed versions:",
+	"audit.ok":                     " - %s: no known vulnerabilities\n",
+	"audit.vulnerable.one":         " - %s: fixed by the security release %s; upgrade to %s\n",
+	"audit.vulnerable.other":       " - %s: fixed by the security releases %s; upgrade to %s\n",
+	"audit.no_vulnerabilities":     "No installed versions have known vulnerabilities",
+
+	// Información de versiones
+	"info.not_found":      "version %s is neither published in the mirror nor installed",
+	"info.date":           "Release date",
+	"info.security":       "Security release",
+	"info.npm":            "npm",
+	"info.v8":             "V8",
+	"info.uv":             "libuv",
+	"info.zlib":           "zlib",
+	"info.openssl":        "OpenSSL",
+	"info.modules":        "Native module ABI",
+	"info.files":          "Published platforms",
+	"info.available":      "Available for %s",
+	"info.installed":      "Installed",
+	"info.current_marker": " (current)",
+	"info.path":           "Path",
+	"info.size":           "Size",
+	"info.aliases":        "Aliases",
+	"info.abi":            "ABI compatibility",
+	"info.abi_same":       "same as the current version (v%s); native modules are compatible",
+	"info.abi_different":  "different from the current version (v%s); native modules must be rebuilt",
+	"info.yes":            "yes",
+	"info.no":             "no",
+
+	// Uso de versiones
+	"usage.read_error":     "Error reading file %s: %v",
+	"usage.decode_error":   "The file %s is not valid: %v",
+	"usage.write_error":    "Error saving the last use date: %v",
+	"usage.record_warning": "Warning: %v",
+
+	// Limpieza de versiones
+	"prune.no_policy":     "Give a policy: --keep <n>, --unused <duration> or both",
+	"prune.nothing":       "There are no versions to remove\n",
+	"prune.dry_run_title": "Versions that would be removed:\n",
+	"prune.title":         "The following versions will be removed:\n",
+	"prune.candidate":     " - %s (last used: %s, %s)\n",
+	"prune.total":         "Space to reclaim: %s\n",
+	"prune.confirm":       "Do you want to remove these versions?",
+	"prune.cancelled":     "Prune cancelled\n",
+	"prune.done.one":      "Removed %d version (%s freed)\n",
+	"prune.done.other":    "Removed %d versions (%s freed)\n",
+
+	// Archivos PAC
+	"pac.parse_error":            "Error parsing the PAC file: %v",
+	"pac.run_error":              "Error running the PAC file: %v",
+	"pac.missing_function":       "The PAC file does not define the FindProxyForURL function",
+	"pac.eval_error":             "Error evaluating FindProxyForURL: %v",
+	"pac.unsupported_proxy_type": "Unsupported proxy type: %s",
+	"pac.missing_proxy_host":     "Missing host for proxy %s",
+	"pac.unclosed_comment":       "line %d: unterminated comment",
+	"pac.invalid_number":         "line %d: invalid number %s",
+	"pac.line_error":             "line %d: %v",
+	"pac.unexpected_char":        "line %d: unexpected character %q",
+	"pac.unclosed_string":        "unterminated string",
+	"pac.expected":               "line %d: expected %q but found %q",
+	"pac.expected_identifier":    "line %d: expected an identifier but found %q",
+	"pac.expected_brace":         "line %d: expected \"}\"",
+	"pac.invalid_assignment":     "line %d: invalid assignment",
+	"pac.unexpected_eof":         "line %d: unexpected end of file",
+	"pac.unexpected_token":       "line %d: unexpected token %q",
+	"pac.unsupported_keyword":    "line %d: the %s statement is not supported in PAC files",
+	"pac.unsupported_operator":   "line %d: the %s operator is not supported in PAC files",
+	"pac.unsupported_object":     "line %d: object literals are not supported in PAC files",
+	"pac.unsupported_regexp":     "line %d: regular expressions are not supported in PAC files",
+	"pac.step_limit":             "the script exceeded the execution limit",
+	"pac.not_a_function":         "the value is not a function",
+	"pac.unsupported_statement":  "unsupported statement",
+	"pac.not_defined":            "%s is not defined",
+	"pac.unsupported_expression": "unsupported expression",
+	"pac.array_index_assignment": "only arrays support assignment by index",
+	"pac.invalid_index":          "invalid index",
+	"pac.unsupported_assignment": "unsupported assignment",
+	"pac.undefined_property":     "cannot read property %s of undefined",
+	"pac.undefined_index":        "cannot read an index of undefined",
+	"pac.undefined_method":       "cannot call method %s of undefined",
+	"pac.unsupported_method":     "unsupported method: %s",
+}
@@ -0,0 +1,637 @@
+package i18n
+
+// es es el catálogo de mensajes en español, el idioma por defecto
+var es = map[string]string{
+	// Configuración
+	"config.key.mirror":                "URL base del repositorio de versiones de Node",
+	"config.key.http_proxy":            "URL del proxy HTTP utilizado para las descargas",
+	"config.key.https_proxy":           "URL del proxy utilizado para las descargas HTTPS (por defecto, http_proxy)",
+	"config.key.no_proxy":              "Lista separada por comas de hosts, dominios o redes que no utilizan el proxy",
+	"config.key.proxy_pac":             "URL o ruta del archivo de configuración automática de proxy (PAC)",
+	"config.key.ca_file":               "Archivos PEM con certificados raíz adicionales, separados por el separador de PATH",
+	"config.key.client_cert":           "Certificado de cliente (PEM) para TLS mutuo con el mirror",
+	"config.key.client_key":            "Clave privada (PEM) del certificado de cliente",
+	"config.key.tls_min_version":       "Versión mínima de TLS (1.0, 1.1, 1.2, 1.3)",
+	"config.key.insecure":              "Desactivar la verificación de certificados TLS (sólo para diagnóstico)",
+	"config.key.parallel_downloads":    "Cantidad máxima de descargas simultáneas en 'install'",
+	"config.key.arch":                  "Arquitectura por defecto de las versiones descargadas (x64, x86, arm64)",
+	"config.key.cache_ttl":             "Tiempo de validez del índice de versiones descargado",
+	"config.key.cache_max_size":        "Tamaño máximo de la caché de descargas (0 para no limitar)",
+	"config.key.timeout":               "Tiempo máximo de espera para conectar con el servidor",
+	"config.key.lang":                  "Idioma de los mensajes (es, en; por defecto, el idioma del sistema)",
+	"config.key.auto_install":          "Instalar automáticamente la versión indicada en 'use' si no está instalada",
+	"config.key.backup_dir":            "Directorio de las copias de seguridad (por defecto, el espacio de trabajo)",
+	"config.key.backup_format":         "Formato de las copias de seguridad (zip, tar.gz, tar.zst)",
+	"config.key.link_mode":             "Forma de activar la versión actual (copy, symlink)",
+	"config.key.default_packages":      "Archivo con los paquetes globales de npm que se instalan en cada versión nueva (por defecto, default-packages en el espacio de trabajo)",
+	"config.key.schedule_url":          "URL del calendario de publicaciones de Node (schedule.json)",
+	"config.read_error":                "Error al leer el archivo de configuración %s: %w",
+	"config.decode_error":              "Error al decodificar el archivo de configuración %s: %w",
+	"config.unsupported_version":       "El archivo de configuración %s usa la versión %d, no soportada por esta versión de polynode",
+	"config.serialize_error":           "Error al serializar la configuración: %w",
+	"config.write_error":               "Error al escribir el archivo de configuración: %w",
+	"config.legacy_open_error":         "Error al abrir el archivo %s: %w",
+	"config.legacy_decode_error":       "Error al decodificar el archivo %s: %w",
+	"config.legacy_rename_error":       "Error al renombrar el archivo %s: %w",
+	"config.unknown_key":               "Clave de configuración desconocida: %s",
+	"config.invalid_value":             "Valor inválido para %s: %w",
+	"config.invalid_duration":          "duración inválida: %s",
+	"config.invalid_size":              "tamaño inválido: %s",
+	"config.expected_positive_int":     "se esperaba un número entero mayor que cero",
+	"config.expected_bool":             "se esperaba true o false",
+	"config.expected_url":              "se esperaba una URL http:// o https://",
+	"config.expected_one_of":           "se esperaba uno de: %s",
+	"config.legacy_migrated":           "Se migró la configuración de %s a %s\n",
+	"config.env_invalid_ignored":       "Se ignora el valor inválido de %s: %s",
+	"config.file_invalid_ignored":      "Se ignora el valor inválido de %s en %s: %s",
+	"config.project_sensitive_ignored": "Se ignora %s en %s: las opciones de red y seguridad sólo se aceptan en la configuración del usuario",
+	"config.invalid_workspace":         "Espacio de trabajo inválido: %v",
+	"config.updated":                   "Se actualizó la configuración: %s = %s\n",
+	"config.removed":                   "Se eliminó la configuración de %s\n",
+	"config.file":                      "Archivo de configuración: %s\n",
+	"config.project_file":              "Configuración del proyecto: %s\n",
+
+	// Credenciales
+	"credentials.read_error":      "Error al leer el archivo de credenciales: %w",
+	"credentials.decode_error":    "Error al decodificar el archivo de credenciales: %w",
+	"credentials.remove_error":    "Error al eliminar el archivo de credenciales: %w",
+	"credentials.serialize_error": "Error al serializar las credenciales: %w",
+	"credentials.write_error":     "Error al escribir el archivo de credenciales: %w",
+
+	// Node
+	"node.version_error": "Error al obtener la versión de Node.js: %v",
+
+	// Archivos
+	"archive.invalid_path":       "El archivo contiene una ruta inválida: %s",
+	"archive.invalid_link":       "El archivo contiene un enlace simbólico que apunta fuera del directorio destino: %s -> %s",
+	"archive.symlink_in_path":    "El archivo contiene una ruta que pasa por un enlace simbólico: %s",
+	"archive.unsupported_format": "Formato de archivo no soportado: %s",
+	"archive.tar_error":          "Error al ejecutar tar: %v %s",
+
+	// Caché de descargas
+	"cache.read_error":        "Error al leer la caché de descargas: %v",
+	"cache.mkdir_error":       "Error al crear el directorio de la caché: %v",
+	"cache.create_file_error": "Error al crear el archivo %s: %v",
+	"cache.save_file_error":   "Error al guardar el archivo %s: %v",
+	"cache.sha_mismatch":      "El SHA-256 de %s no coincide con %s (esperado %s, obtenido %s)",
+	"cache.move_error":        "Error al mover %s a la caché: %v",
+	"cache.remove_error":      "Error al eliminar %s de la caché: %v",
+
+	// Checksums
+	"checksums.get_error":        "Error al obtener %s: %v",
+	"checksums.get_status_error": "Error al obtener %s: %s",
+	"checksums.read_error":       "Error al leer %s: %v",
+	"checksums.save_error":       "Error al guardar %s en la caché: %v",
+	"checksums.missing_file":     "%s no incluye el SHA-256 de %s; no se puede verificar el archivo",
+
+	// Red
+	"http.invalid_proxy_url": "Error al interpretar la URL del proxy %s: %v",
+	"http.client_error":      "No se pudo procesar la configuración de red (proxy o TLS)",
+	"http.insecure_warning":  "ADVERTENCIA: la verificación de certificados TLS está DESACTIVADA.\nLas descargas pueden ser interceptadas o modificadas por terceros.\nUtilice la opción insecure sólo para diagnóstico y desactívela con:\n  poly config unset insecure",
+	"http.pac_detected":      "Configuración automática de proxy (PAC) detectada",
+	"http.proxy_detected":    "Configuración de proxy detectada",
+
+	// Índice de versiones
+	"index.decode_error":      "Error al decodificar el contenido del archivo index.json: %v",
+	"index.expired":           "El índice de versiones guardado está vencido",
+	"http.request_error":      "Error al crear la solicitud HTTP: %v",
+	"index.request_error":     "Error al realizar la solicitud HTTP para obtener información sobre las versiones disponibles: %v",
+	"index.status_error":      "Error al obtener información sobre las versiones disponibles: %s",
+	"index.read_error":        "Error al leer el contenido del archivo index.json: %v",
+	"index.no_match":          "No se encontró ninguna versión que coincida con %s",
+	"index.no_lts":            "No se pudo obtener la versión LTS",
+	"index.stale_fallback":    "No se pudo obtener el índice de versiones del mirror, se usa el índice guardado",
+	"schedule.decode_error":   "Error al decodificar el contenido del archivo schedule.json: %v",
+	"schedule.request_error":  "Error al realizar la solicitud HTTP para obtener el calendario de publicaciones: %v",
+	"schedule.status_error":   "Error al obtener el calendario de publicaciones: %s",
+	"schedule.read_error":     "Error al leer el contenido del archivo schedule.json: %v",
+	"schedule.stale_fallback": "No se pudo obtener el calendario de publicaciones, se usa el calendario guardado",
+
+	// Instalación
+	"install.failed_count":      "No se pudieron instalar %d de %d versiones",
+	"install.mkdir_error":       "Error al crear el directorio de instalación: %v",
+	"install.extract_error":     "Error al extraer el archivo: %v",
+	"install.download_error":    "Error al obtener el archivo %s: %v",
+	"install.version_not_found": "No se pudo encontrar la versión especificada: %s",
+
+	// Versiones instaladas
+	"list.read_error":        "Error al leer la carpeta de instalación: %v",
+	"list.not_installed":     "La versión %s no está instalada",
+	"version.invalid_format": "Formato de versión inválido: %s",
+	"version.invalid_major":  "Error al convertir la parte mayor: %v",
+	"version.invalid_minor":  "Error al convertir la parte menor: %v",
+	"version.invalid_patch":  "Error al convertir la parte de revisión: %v",
+
+	// Proxy
+	"pac.download_error":        "Error al descargar el archivo PAC %s: %v",
+	"pac.download_status_error": "Error al descargar el archivo PAC %s: %s",
+	"pac.read_error":            "Error al leer el archivo PAC %s: %v",
+	"pac.invalid_url":           "URL del archivo PAC inválida: %v",
+	"pac.unsupported_result":    "El archivo PAC no devolvió un proxy soportado: %s",
+	"proxy.config_error":        "Error al configurar el proxy: %w",
+	"proxy.https_updated":       "Se actualizó la configuración para usar el proxy HTTPS '%s'\n",
+	"proxy.bypass_updated":      "Se actualizó la lista de hosts que no utilizan el proxy: %s\n",
+	"proxy.pac_updated":         "Se actualizó la configuración para usar el archivo PAC '%s'\n",
+	"proxy.save_error":          "Error al guardar la configuración del proxy: %w",
+	"proxy.updated":             "Se actualizó la configuración para usar el proxy '%s'\n",
+	"proxy.missing_user":        "Debe indicar el nombre de usuario del proxy",
+	"proxy.password_prompt":     "Contraseña del proxy para %s: ",
+	"proxy.password_error":      "Error al leer la contraseña: %w",
+	"proxy.password_echo_error": "No se puede ocultar la contraseña en esta terminal (%v); indíquela en la variable de entorno %s o por la entrada estándar",
+	"proxy.credentials_saved":   "Se guardaron las credenciales del proxy en %s\n",
+	"proxy.cleared":             "Se eliminó la configuración del proxy",
+	"proxy.none":                "No hay un proxy configurado en polynode, se usan las variables de entorno HTTP_PROXY, HTTPS_PROXY y NO_PROXY",
+
+	// TLS
+	"tls.ca_read_error":          "Error al leer el archivo de certificados %s: %v",
+	"tls.ca_invalid":             "El archivo %s no contiene certificados PEM válidos",
+	"tls.client_cert_incomplete": "Para usar un certificado de cliente deben configurarse client_cert y client_key",
+	"tls.client_cert_error":      "Error al cargar el certificado de cliente: %v",
+
+	// Desinstalación
+	"uninstall.not_installed": "La versión %s no está instalada",
+	"uninstall.error":         "Error al desinstalar la versión %s: %w",
+	"uninstall.current_error": "Error al desinstalar la versión actual: %w",
+
+	// Selección de versión
+	"use.not_installed":        "La versión especificada de Node no está instalada: %s",
+	"use.auto_install":         "La versión %s no está instalada, se instalará automáticamente",
+	"use.already_selected":     "La versión %s ya está seleccionada, no es necesario cambiar de versión",
+	"use.remove_link_error":    "Error al eliminar el enlace current: %v",
+	"use.move_previous_error":  "Error al mover la versión anterior: %v",
+	"use.symlink_error":        "Error al crear el enlace simbólico: %v",
+	"use.copy_error":           "Error al copiar los archivos: %v",
+	"use.rename_current_error": "Error al renombrar current: %v",
+
+	// Línea de comandos
+	"global.flag.workspace":    "Espacio de trabajo a utilizar (por defecto, POLYNODE_PATH)",
+	"global.flag.output":       "Formato de salida de los comandos que lo admiten",
+	"global.flag.json":         "Equivale a --output json",
+	"global.flag.quiet":        "Mostrar sólo los errores y las advertencias",
+	"global.flag.verbose":      "Mostrar información adicional para diagnóstico",
+	"global.flag.no-color":     "No utilizar colores ni secuencias de control de la terminal",
+	"global.flag.yes":          "Responder que sí a todas las confirmaciones",
+	"global.flag.help":         "Mostrar la ayuda del comando",
+	"cli.invalid_workspace":    "Espacio de trabajo inválido: %v",
+	"cli.workspace_error":      "Error al crear la carpeta de instalación: %v",
+	"cli.debug.workspace":      "Espacio de trabajo: %s",
+	"cli.debug.config":         "Configuración: %s",
+	"cli.debug.project_config": "Configuración del proyecto: %s",
+	"cli.debug.mirror":         "Mirror: %s (%s)",
+	"cli.debug.language":       "Idioma: %s",
+	"cli.debug.exit_code":      "Código de salida: %d (%s)",
+	"cli.missing_flag_value":   "Falta el valor del flag %s",
+	"cli.unknown_option":       "Opción desconocida: %s%s",
+	"cli.unknown_command":      "Comando desconocido: %s%s",
+	"cli.unknown_subcommand":   "Subcomando de %s desconocido: %s%s",
+	"cli.subcommand_usage":     "Uso: poly %s <%s>",
+	"cli.did_you_mean":         "\n¿Quiso decir \"%s\"?",
+	"cli.see_help":             "\nUtilice \"poly help\" para ver los comandos y opciones disponibles.",
+	"cli.command_usage":        "Uso: poly %s",
+	"cli.invalid_flag_value":   "Valor inválido para %s: %s",
+
+	// Ayuda
+	"help.usage":                  "Uso: poly %s",
+	"help.subcommand_placeholder": "<subcomando>",
+	"help.options_placeholder":    "[opciones]",
+	"help.subcommands":            "Subcomandos:",
+	"help.options":                "Opciones:",
+	"help.structured":             "Admite --output json|yaml para generar una salida para scripts.",
+	"help.global_options_hint":    "Opciones globales: %s (ver poly help)",
+	"help.root_usage":             "Uso: poly <comando> [parámetros]",
+	"help.commands":               "Comandos",
+	"help.global_options":         "Opciones globales",
+	"help.exit_codes":             "Códigos de salida",
+	"help.exit.ok":                "Ejecución correcta",
+	"help.exit.error":             "Error general",
+	"help.exit.usage":             "Comando, subcomando u opción inválidos",
+	"help.exit.not_found":         "La versión no existe en el mirror o no está instalada",
+	"help.exit.network":           "Error de red al acceder al mirror",
+	"help.exit.integrity":         "Un archivo no coincide con el SHA-256 esperado",
+	"help.exit.eol":               "Alguna versión instalada llegó al fin de vida (outdated --fail-on-eol)",
+	"help.exit.vulnerable":        "Alguna versión instalada tiene vulnerabilidades conocidas (audit)",
+	"help.command_help_hint":      "Utilice \"poly <comando> --help\" para ver la ayuda de un comando.",
+
+	// Parámetros de la ayuda
+	"arg.directorio":  "directorio",
+	"arg.archivo":     "archivo",
+	"arg.archivo.tar": "archivo.tar",
+	"arg.clave":       "clave",
+	"arg.valor":       "valor",
+	"arg.comando":     "comando",
+	"arg.destino":     "destino",
+	"arg.formato":     "formato",
+	"arg.duración":    "duración",
+	"arg.dirección":   "dirección",
+	"arg.usuario":     "usuario",
+	"arg.versión":     "versión",
+	"arg.contraseña":  "contraseña",
+	"arg.nombre":      "nombre",
+	"arg.origen":      "origen",
+	"arg.gestor":      "gestor",
+
+	// Comandos
+	"cmd.install.summary":                      "Instalar una o más versiones de node en el repositorio local (20, 20.11, 20.11.0 o lts)",
+	"cmd.use.summary":                          "Usar versión de node previamente instalada (sin parámetros, la del alias default)",
+	"cmd.list.summary":                         "Lista versiones de node instaladas en el repositorio local",
+	"cmd.version.summary":                      "Muestra la versión de Node seleccionada",
+	"cmd.ls-remote.summary":                    "Lista las versiones publicadas en el mirror",
+	"cmd.info.summary":                         "Mostrar los componentes, plataformas y datos de instalación de una versión",
+	"cmd.info.description":                     "Incluye las versiones de npm, V8, libuv, zlib, OpenSSL y la ABI de los módulos nativos (modules), e\nindica si la ABI coincide con la de la versión actual.",
+	"cmd.uninstall.summary":                    "Eliminar del repositorio local la versión de node especificada",
+	"cmd.outdated.summary":                     "Mostrar las versiones instaladas con revisiones más nuevas o sin soporte",
+	"cmd.outdated.description":                 "El estado de cada línea (actual, LTS activo, mantenimiento o fin de vida) y la fecha de fin de soporte\nse obtienen del calendario de publicaciones de Node (schedule_url).",
+	"cmd.outdated.flag.fail-on-eol":            "Terminar con el código de salida 6 si alguna versión llegó al fin de vida",
+	"cmd.audit.summary":                        "Buscar versiones instaladas con vulnerabilidades de seguridad conocidas",
+	"cmd.audit.description":                    "Una versión es vulnerable si después se publicó una versión de seguridad de su misma línea (campo\nsecurity de index.json). Si hay alguna, el comando termina con el código de salida 7.",
+	"cmd.upgrade.summary":                      "Actualizar una versión a la más nueva de su línea",
+	"cmd.upgrade.description":                  "Sin parámetros se actualiza la versión actual. La versión nueva se instala con los paquetes globales\nde npm de la anterior, se selecciona si la anterior era la actual y los alias que apuntaban a la\nanterior pasan a apuntar a la nueva.",
+	"cmd.upgrade.flag.patch-only":              "Buscar sólo en la misma versión menor (20.11.x)",
+	"cmd.upgrade.flag.prune":                   "Desinstalar la versión anterior",
+	"cmd.prune.summary":                        "Desinstalar las versiones antiguas o sin usar",
+	"cmd.prune.description":                    "Si se indican --keep y --unused, se eliminan las versiones que cumplen ambas condiciones. La versión\nactual y las versiones con alias nunca se eliminan. La fecha de último uso la guardan poly use y\npoly shell; si una versión nunca se usó, se toma la fecha de instalación.",
+	"cmd.prune.flag.keep":                      "Conservar las n versiones más nuevas de cada versión mayor",
+	"cmd.prune.flag.unused":                    "Eliminar las versiones que no se usaron en ese tiempo (por ejemplo 90d)",
+	"cmd.prune.flag.dry-run":                   "Mostrar qué versiones se eliminarían sin eliminarlas",
+	"cmd.proxy.summary":                        "Utilizar la url de proxy indicada para la descarga de versiones de Node",
+	"cmd.config.summary":                       "Consultar o modificar la configuración de polynode",
+	"cmd.config.list.summary":                  "Mostrar todas las claves con su valor y su origen",
+	"cmd.config.get.summary":                   "Mostrar el valor de una clave",
+	"cmd.config.set.summary":                   "Guardar el valor de una clave en config.json",
+	"cmd.config.unset.summary":                 "Eliminar una clave de config.json",
+	"cmd.bundle.summary":                       "Crear o importar paquetes para instalaciones sin conexión",
+	"cmd.bundle.create.summary":                "Crear un paquete para instalaciones sin conexión",
+	"cmd.bundle.import.summary":                "Importar un paquete a la caché de descargas",
+	"cmd.cache.summary":                        "Administrar la caché de descargas",
+	"cmd.cache.list.summary":                   "Listar los archivos de la caché de descargas",
+	"cmd.cache.clean.summary":                  "Limpiar la caché de descargas",
+	"cmd.serve.summary":                        "Servir la caché de descargas como mirror de Node para la red local",
+	"cmd.check.summary":                        "Revisar configuración de la instalación de polynode",
+	"cmd.backup.summary":                       "Realiza una copia de seguridad del repositorio y la versión actual",
+	"cmd.backup.list.summary":                  "Lista las copias de seguridad del espacio de trabajo",
+	"cmd.restore.summary":                      "Restaurar una copia de seguridad (guarda antes el estado actual)",
+	"cmd.export.summary":                       "Exportar el entorno (versiones, configuración y paquetes globales) a un manifiesto JSON",
+	"cmd.import.summary":                       "Reproducir el entorno descrito en un manifiesto",
+	"cmd.shell.summary":                        "Abrir shell con la versión actual de Node.js configurada en el PATH",
+	"cmd.help.summary":                         "Mostrar esta ayuda o la ayuda de un comando",
+	"cmd.install.description":                  "Las versiones parciales se resuelven con el índice del mirror. Con --from se instala sin conexión\ndesde un archivo (.zip, .tar.gz o .tar.xz) o un directorio local. En cada versión nueva se instalan\nlos paquetes globales del archivo default_packages.",
+	"cmd.restore.description":                  "Con --yes se omite la confirmación.",
+	"cmd.install.flag.from":                    "Instalar sin conexión desde un archivo o directorio local",
+	"cmd.install.flag.shasums":                 "Validar el archivo de --from contra un SHASUMS256.txt local",
+	"cmd.install.flag.reinstall-packages-from": "Instalar también los paquetes globales de npm de otra versión instalada",
+	"cmd.install.flag.exact":                   "Con --reinstall-packages-from, conservar las versiones de los paquetes",
+	"cmd.ls-remote.flag.lts":                   "Mostrar sólo las versiones LTS",
+	"cmd.proxy.flag.https":                     "Utilizar un proxy distinto para las descargas HTTPS",
+	"cmd.proxy.flag.bypass":                    "Lista de hosts, dominios o redes que no utilizan el proxy",
+	"cmd.proxy.flag.pac":                       "Obtener el proxy de cada descarga desde un archivo PAC",
+	"cmd.proxy.flag.user":                      "Guardar las credenciales del proxy (solicita la contraseña sin mostrarla)",
+	"cmd.proxy.flag.clear":                     "Eliminar la configuración del proxy",
+	"cmd.bundle.create.flag.versions":          "Versiones a incluir",
+	"cmd.bundle.create.flag.platforms":         "Plataformas a incluir (por defecto, la actual)",
+	"cmd.cache.clean.flag.older-than":          "Eliminar sólo los archivos sin usar desde hace más tiempo (por ejemplo 30d)",
+	"cmd.serve.flag.addr":                      "Dirección en la que escuchar (por defecto :8080)",
+	"cmd.serve.flag.pull-through":              "Descargar del mirror configurado las versiones que no estén en la caché",
+	"cmd.backup.flag.output":                   "Directorio o archivo de destino",
+	"cmd.backup.flag.format":                   "Formato de la copia (zip, tar.gz, tar.zst)",
+	"cmd.backup.flag.level":                    "Nivel de compresión",
+	"cmd.backup.flag.keep":                     "Conservar sólo las n copias más recientes",
+	"cmd.import.flag.with-config":              "Aplicar también la configuración del manifiesto",
+	"cmd.import.flag.no-globals":               "No instalar los paquetes globales de npm",
+	"use.changed":                              "Se cambió la versión a v%s\n",
+	"use.security_warning":                     "Advertencia: hay versiones de seguridad posteriores a Node v%s en su línea (%s); actualice con poly upgrade %s\n",
+	"uninstall.failed":                         "Error al desinstalar la versión: %w",
+	"shell.error":                              "Error al abrir el shell: %w",
+	"version.none_selected":                    "No hay ninguna versión seleccionada. Utilice el comando use para seleccionar una.",
+	"uninstall.current_removed":                "La versión actual ha sido desinstalada. Por favor, selecciona una nueva versión usando 'use'\n",
+	"uninstall.done":                           "La versión %s ha sido desinstalada correctamente\n",
+	"list.empty":                               "No hay versiones de node instaladas en el repositorio local.",
+	"list.empty_hint":                          "Utilice el comando poly install <version> para instalar una.",
+	"list.title":                               "Versiones instaladas:",
+	"list.current_line":                        " - [%s] <- ACTUAL",
+	"install.summary":                          "\nResumen de la instalación:\n",
+	"install.summary_error":                    " - %s: ERROR %v\n",
+	"install.summary_installed":                " - %s: instalada\n",
+	"install.done":                             "Versiones instaladas en %s\n",
+	"npm.read_globals_error":                   "Error al leer los paquetes globales de %s: %v",
+	"npm.not_found":                            "No se encontró npm en %s",
+	"npm.install_globals_error":                "Error al instalar los paquetes globales: %v",
+	"cache.cleaned.one":                        "Se eliminó %d archivo de la caché (%s liberados)\n",
+	"cache.cleaned.other":                      "Se eliminaron %d archivos de la caché (%s liberados)\n",
+	"cache.empty":                              "La caché de descargas está vacía.",
+	"cache.title":                              "Archivos en la caché de descargas:",
+	"cache.total":                              "Total: %s (límite: %s)\n",
+	"lsremote.current_marker":                  " <- ACTUAL",
+	"lsremote.installed_marker":                " [instalada]",
+	"lsremote.empty":                           "No se encontraron versiones que coincidan en el mirror.",
+	"lsremote.title":                           "Versiones disponibles:",
+	"check.where_error":                        "Error al ejecutar 'where node': %w",
+	"check.where_start_error":                  "Error al iniciar el comando 'where node': %w",
+	"check.where_wait_error":                   "Error al esperar el comando 'where node': %w",
+	"check.not_found":                          "No se encontraron ubicaciones para el ejecutable de Node.js.",
+	"check.fix_hint":                           "Por favor, actualice el PATH del sistema con el siguiente comando:",
+	"check.mismatch":                           "La ubicación del ejecutable de Node.js no coincide con la ubicación esperada.",
+	"check.first_mismatch":                     "La primera ubicación del ejecutable de Node.js no coincide con la ubicación esperada.",
+	"check.ok":                                 "La ubicación del ejecutable de Node.js coincide con la ubicación esperada.",
+	"check.first_ok":                           "La primera ubicación del ejecutable de Node.js coincide con la ubicación esperada.",
+	"check.multiple":                           "Se encontraron múltiples ubicaciones del ejecutable de Node. Se recomienda eliminar las ubicaciones adicionales del PATH correspondientes a otras instalaciones de Node para evitar conflictos.",
+	"serve.pull_through":                       "Las versiones que no estén en la caché se descargarán de %s\n",
+	"serve.listening":                          "Sirviendo la caché de descargas en http://%s/\n",
+	"serve.stop_hint":                          "Presiona Ctrl+C para detener el servidor",
+	"serve.method_not_allowed":                 "método no permitido",
+	"serve.index_unavailable":                  "index.json no disponible",
+	"serve.index_invalid":                      "index.json inválido",
+	"shell.no_version":                         "no hay ninguna versión seleccionada. Utilice el comando 'use' para seleccionar una versión",
+	"shell.not_installed":                      "la versión actual no está instalada correctamente",
+	"shell.opening":                            "Abriendo shell con Node.js v%s...\n",
+	"shell.node_dir":                           "Directorio de Node.js: %s\n",
+	"shell.exit_hint":                          "Presiona Ctrl+C para salir del shell",
+	"npm.installing_globals.one":               "Instalando %d paquete global en Node v%s...\n",
+	"npm.installing_globals.other":             "Instalando %d paquetes globales en Node v%s...\n",
+	"cmd.completion.summary":                   "Generar el script de autocompletado para bash, zsh, fish o PowerShell",
+	"cmd.completion.description":               "Para activarlo:\n  bash:       source <(poly completion bash)\n  zsh:        poly completion zsh > \"${fpath[1]}/_poly\"\n  fish:       poly completion fish > ~/.config/fish/completions/poly.fish\n  PowerShell: poly completion powershell | Out-String | Invoke-Expression",
+	"completion.unknown_shell":                 "Shell no soportado: %s (valores posibles: %s)",
+	"cmd.alias.summary":                        "Administrar los nombres asignados a versiones de node",
+	"cmd.alias.description":                    "Un alias se puede usar en lugar de una versión (poly use trabajo). El alias default es la versión que\nse selecciona con poly use sin parámetros.",
+	"cmd.alias.set.summary":                    "Asignar un nombre a una versión (18.19.0, 20 o lts)",
+	"cmd.alias.list.summary":                   "Listar los alias y la versión a la que apuntan",
+	"cmd.alias.rm.summary":                     "Eliminar un alias",
+	"cmd.alias.refresh.summary":                "Volver a resolver los alias de líneas de versiones (lts, 20) con el índice del mirror",
+	"npm.default_packages_error":               "Error al leer el archivo de paquetes por defecto %s: %v",
+	"npm.nothing_to_migrate":                   "Node v%s no tiene paquetes globales que falten en Node v%s\n",
+	"npm.migrating.one":                        "Migrando %d paquete global de Node v%s a Node v%s...\n",
+	"npm.migrating.other":                      "Migrando %d paquetes globales de Node v%s a Node v%s...\n",
+	"npm.migrated":                             "Se instalaron los paquetes globales en Node v%s\n",
+	"cmd.migrate-globals.summary":              "Instalar en una versión los paquetes globales de npm de otra",
+	"cmd.migrate-globals.description":          "Se instalan las versiones más recientes de los paquetes, salvo con --exact.",
+	"cmd.migrate-globals.flag.exact":           "Conservar las versiones de los paquetes",
+	"cmd.pm.summary":                           "Administrar los gestores de paquetes (pnpm, Yarn) con Corepack",
+	"cmd.pm.description":                       "Los shims de pnpm y yarn se crean en el directorio de la versión de Node, por lo que están disponibles\nen el PATH de la versión actual y en poly shell sin instalarlos como paquetes globales. Sin parámetros,\npoly pm use usa el campo packageManager del package.json del proyecto.",
+	"cmd.pm.use.summary":                       "Habilitar un gestor de paquetes y activar la versión indicada",
+	"cmd.pm.use.flag.node":                     "Versión de Node en la que se habilita (por defecto, la actual)",
+	"cmd.pm.disable.summary":                   "Eliminar los shims de los gestores de paquetes",
+	"cmd.pm.disable.flag.node":                 "Versión de Node de la que se eliminan (por defecto, la actual)",
+
+	// Salida
+	"output.invalid_format": "Formato de salida inválido: %s (valores posibles: json, yaml, text)",
+	"output.encode_error":   "Error al generar la salida: %v",
+	"output.unsupported":    "El comando %s no admite --output json|yaml",
+
+	// Copias de seguridad
+	"backup.created":               "Se creó una copia de seguridad en: %s\n",
+	"backup.pruned":                "Se eliminó la copia de seguridad anterior %s\n",
+	"backup.unsupported_format":    "Formato de copia de seguridad no soportado: %s (zip, tar.gz, tar.zst)",
+	"backup.inside_workspace":      "La copia de seguridad no se puede guardar dentro de %s",
+	"backup.destination_error":     "error al crear el directorio de destino: %w",
+	"backup.create_error":          "error al crear el archivo de copia de seguridad: %w",
+	"backup.add_error":             "error al agregar archivos de '%s' a la copia de seguridad: %w",
+	"backup.write_error":           "error al escribir la copia de seguridad: %w",
+	"backup.read_dir_error":        "error al leer el directorio de copias de seguridad: %w",
+	"backup.remove_error":          "error al eliminar la copia de seguridad %s: %w",
+	"backup.list_empty":            "No hay copias de seguridad en %s.\n",
+	"backup.list_empty_hint":       "Utilice el comando poly backup para crear una.",
+	"backup.list_title":            "Copias de seguridad:",
+	"backup.zstd_error":            "No se pudo ejecutar zstd (necesario para el formato tar.zst): %v",
+	"backup.invalid_level":         "El nivel de compresión para %s debe estar entre 1 y %d",
+	"backup.command_error":         "Error al ejecutar %s: %v",
+	"backup.zstd_decompress_error": "Error al descomprimir con zstd: %v",
+	"backup.unsupported_file":      "Formato de copia de seguridad no soportado: %s",
+
+	// Paquetes sin conexión
+	"bundle.created.one":      "Se creó el paquete %s con %d archivo (%s)\n",
+	"bundle.created.other":    "Se creó el paquete %s con %d archivos (%s)\n",
+	"bundle.imported.one":     "Se importó %d archivo a la caché de descargas\n",
+	"bundle.imported.other":   "Se importaron %d archivos a la caché de descargas\n",
+	"bundle.index_error":      "No se pudo obtener el índice de versiones: %v",
+	"bundle.create_error":     "Error al crear el archivo %s: %v",
+	"bundle.missing_platform": "La versión %s no tiene un archivo publicado para la plataforma %s",
+	"bundle.using_cache":      "Usando el archivo %s de la caché de descargas\n",
+	"bundle.manifest_error":   "Error al serializar el manifiesto del paquete: %v",
+	"bundle.open_error":       "Error al abrir el paquete %s: %v",
+	"bundle.read_error":       "Error al leer el paquete %s: %v",
+	"bundle.save_index_error": "Error al guardar el índice de versiones: %v",
+	"bundle.imported_file":    "Importado %s\n",
+	"bundle.write_error":      "Error al escribir %s en el paquete: %v",
+
+	// bundle
+	"bundle.invalid_version":   "Versión no válida en la entrada %s del paquete: %v",
+	"bundle.missing_checksums": "La entrada %s del paquete aparece antes que su archivo %s",
+	"bundle.unlisted_file":     "La entrada %s del paquete no aparece en su archivo %s",
+
+	// Manifiestos de entorno
+	"lock.exported.one":        "Se exportó el entorno a %s (%d versión)\n",
+	"lock.exported.other":      "Se exportó el entorno a %s (%d versiones)\n",
+	"lock.encode_error":        "Error al serializar el manifiesto del entorno: %v",
+	"lock.save_error":          "Error al guardar %s: %v",
+	"lock.no_checksum":         "No se pudo obtener el SHA-256 de %s; la versión se exportará sin verificación\n",
+	"lock.credentials_removed": "Se quitaron el usuario y la contraseña de la configuración %s del manifiesto\n",
+	"lock.read_error":          "Error al leer %s: %v",
+	"lock.invalid":             "El archivo %s no es un manifiesto de entorno válido: %v",
+	"lock.unsupported_version": "Versión de manifiesto no soportada: %d",
+	"lock.config_ignored":      "Se ignora la configuración %s: %v\n",
+	"lock.config_set":          "Configuración %s = %s\n",
+	"lock.config_skipped":      "El manifiesto incluye configuración que no se aplicó; use --with-config para aplicarla\n",
+	"lock.sensitive_config":    "El manifiesto cambia opciones de red y de seguridad:\n",
+	"lock.sensitive_confirm":   "¿Aplicar estas opciones?",
+	"lock.sensitive_skipped":   "No se aplicaron las opciones de red y de seguridad\n",
+	"lock.other_platform":      "El manifiesto es de la plataforma %s; se instalarán las versiones para %s sin verificar el SHA-256 del manifiesto\n",
+	"lock.current":             "Versión actual: %s\n",
+	"lock.imported":            "Se importó el entorno de %s\n",
+	"lock.already_installed":   "La versión %s ya está instalada\n",
+	"lock.checksum_mismatch":   "El SHA-256 de %s en el mirror (%s) no coincide con el del manifiesto (%s)",
+	"lock.alias":               "Alias %s -> %s (%s)\n",
+
+	// Instalación sin conexión
+	"offline.usage":                "Uso: poly install <version> [<version> ...] | poly %s",
+	"offline.versions_with_from":   "No se pueden indicar versiones junto con --from",
+	"offline.access_error":         "No se pudo acceder a %s: %v",
+	"offline.install_dir_error":    "Error al crear el directorio de instalación: %v",
+	"offline.shasums_ignored":      "Se ignora --shasums al instalar desde un directorio",
+	"offline.copying":              "Copiando Node v%s desde %s...\n",
+	"offline.copy_error":           "Error al copiar los archivos: %v",
+	"offline.installed":            "Node v%s instalado en %s\n",
+	"offline.checksum_ok":          "El SHA-256 de %s coincide con %s\n",
+	"offline.temp_error":           "Error al crear el directorio temporal: %v",
+	"offline.extracting":           "Extrayendo archivos...",
+	"offline.extract_error":        "Error al extraer el archivo: %v",
+	"offline.move_error":           "Error al mover los archivos al repositorio: %v",
+	"offline.version_not_detected": "No se pudo detectar la versión de Node en %s",
+	"offline.node_not_found":       "No se encontró el ejecutable de Node para la plataforma %s en %s",
+	"offline.platform_mismatch":    "La plataforma del archivo (%s) no coincide con la plataforma actual (%s)",
+	"offline.already_installed":    "La versión %s ya está instalada",
+	"offline.shasums_open_error":   "Error al abrir %s: %v",
+	"offline.not_in_shasums":       "El archivo %s no figura en %s",
+	"offline.checksum_error":       "Error al calcular el SHA-256 de %s: %v",
+	"offline.checksum_mismatch":    "El SHA-256 de %s no coincide con %s (esperado %s, obtenido %s)",
+	"offline.packages_with_from":   "No se puede indicar --reinstall-packages-from junto con --from",
+
+	// Restauración
+	"restore.confirm":             "¿Desea continuar?",
+	"confirm.prompt":              " [s/N]: ",
+	"restore.summary.one":         "Copia de seguridad: %s (%d archivo, %s)\n",
+	"restore.summary.other":       "Copia de seguridad: %s (%d archivos, %s)\n",
+	"restore.open_error":          "No se pudo abrir la copia de seguridad %s: %v",
+	"restore.verifying":           "Verificando la copia de seguridad...",
+	"restore.invalid":             "La copia de seguridad %s no es válida: %v",
+	"restore.cancelled":           "Restauración cancelada",
+	"restore.safety_error":        "No se pudo crear la copia de seguridad del estado actual: %v",
+	"restore.safety_saved":        "Se guardó el estado anterior en: %s\n",
+	"restore.done":                "Se restauró la copia de seguridad %s\n",
+	"restore.unexpected_file":     "contiene un archivo inesperado: %s",
+	"restore.invalid_path":        "contiene una ruta inválida: %s",
+	"restore.empty":               "no contiene archivos",
+	"restore.no_manifest":         "La copia de seguridad no incluye un manifiesto; no se verificarán los hashes de los archivos",
+	"restore.checksum_mismatch":   "el SHA-256 de %s no coincide con el manifiesto",
+	"restore.verified":            "Se verificó el SHA-256 de todos los archivos",
+	"restore.current_replaced":    " current:    se reemplazará la versión seleccionada (%s)\n",
+	"restore.current_kept":        " current:    no está en la copia de seguridad, se conserva",
+	"restore.repository_replaced": " repository: se reemplazarán las versiones instaladas (%s)\n",
+	"restore.repository_with":     "             por las de la copia de seguridad (%s)\n",
+	"restore.repository_kept":     " repository: no está en la copia de seguridad, se conserva",
+	"restore.none":                "ninguna",
+	"restore.move_error":          "Error al mover %s: %v",
+	"restore.restore_error":       "Error al restaurar %s: %v",
+
+	// Progreso de las descargas
+	"progress.cached":          "en caché",
+	"progress.extracting":      "extrayendo archivos...",
+	"progress.installed":       "instalado",
+	"progress.downloading_url": "Descargando %s",
+	"progress.resolved":        "Versión %s: %s\n",
+	"progress.downloading":     "Descargando archivo %s...\n",
+
+	// Alias
+	"alias.invalid_name":         "Nombre de alias inválido: %s (debe empezar con una letra y no puede ser lts ni current)",
+	"alias.not_found":            "El alias %s no existe",
+	"alias.read_error":           "Error al leer el archivo de alias %s: %w",
+	"alias.decode_error":         "Error al decodificar el archivo de alias %s: %w",
+	"alias.write_error":          "Error al guardar el archivo de alias: %w",
+	"alias.set":                  "Se definió el alias %s -> %s\n",
+	"alias.removed":              "Se eliminó el alias %s\n",
+	"alias.up_to_date":           "Los alias están actualizados\n",
+	"alias.refreshed":            "Se actualizó el alias %s (%s): %s -> %s\n",
+	"alias.empty":                "No hay alias definidos.",
+	"alias.empty_hint":           "Utilice el comando poly alias set <nombre> <version> para crear uno.",
+	"alias.title":                "Alias:",
+	"alias.not_installed_marker": " [no instalada]",
+	"use.no_default":             "Uso: poly use <version>\nNo hay una versión por defecto; se define con poly alias set default <version>.",
+
+	// Gestores de paquetes
+	"pm.no_project":      "no se indicó el gestor de paquetes y el package.json del proyecto no tiene el campo packageManager",
+	"pm.unknown":         "gestor de paquetes no soportado: %s (opciones: %s)",
+	"pm.project":         "Gestor de paquetes del proyecto: %s (%s)\n",
+	"pm.enabled":         "%s habilitado en Node v%s\n",
+	"pm.disabled":        "Se eliminaron los shims de %s de Node v%s\n",
+	"pm.no_corepack":     "Node v%s no incluye Corepack (se incluye desde Node 16.9 y 14.19)",
+	"pm.corepack_error":  "error al ejecutar Corepack: %w",
+	"pm.project_warning": "Advertencia: no se pudo habilitar %s: %v\n",
+
+	// Actualización de versiones
+	"upgrade.up_to_date":        "Node v%s ya es la versión más nueva de su línea\n",
+	"upgrade.upgrading":         "Actualizando Node v%s a v%s\n",
+	"upgrade.already_installed": "Node v%s ya está instalado\n",
+	"upgrade.done":              "Node v%s se actualizó a v%s\n",
+
+	// Versiones desactualizadas
+	"outdated.no_schedule":        "No se pudo obtener el calendario de publicaciones de Node: %v\n",
+	"outdated.eol_found.one":      "hay %d versión instalada que llegó al fin de vida",
+	"outdated.eol_found.other":    "hay %d versiones instaladas que llegaron al fin de vida",
+	"outdated.column.version":     "Versión",
+	"outdated.column.latest":      "Última",
+	"outdated.column.status":      "Estado",
+	"outdated.column.eol":         "Fin de soporte",
+	"outdated.status.current":     "actual",
+	"outdated.status.lts":         "LTS activo",
+	"outdated.status.maintenance": "mantenimiento",
+	"outdated.status.eol":         "fin de vida",
+	"outdated.status.unknown":     "desconocido",
+	"outdated.up_to_date":         "Todas las versiones instaladas tienen la última revisión de su línea",
+	"outdated.summary.one":        "%d versión tiene una revisión más nueva (poly upgrade <versión>)\n",
+	"outdated.summary.other":      "%d versiones tienen una revisión más nueva (poly upgrade <versión>)\n",
+	"outdated.eol_summary.one":    "%d versión llegó al fin de vida\n",
+	"outdated.eol_summary.other":  "%d versiones llegaron al fin de vida\n",
+
+	// Auditoría de seguridad
+	"audit.vulnerable_found.one":   "hay %d versión instalada con vulnerabilidades de seguridad conocidas",
+	"audit.vulnerable_found.other": "hay %d versiones instaladas con vulnerabilidades de seguridad conocidas",
+	"audit.title":                  "Auditoría de seguridad de las versiones instaladas:",
+	"audit.ok":                     " - %s: sin vulnerabilidades conocidas\n",
+	"audit.vulnerable.one":         " - %s: corregida por la versión de seguridad %s; actualizar a %s\n",
+	"audit.vulnerable.other":       " - %s: corregida por las versiones de seguridad %s; actualizar a %s\n",
+	"audit.no_vulnerabilities":     "No hay versiones instaladas con vulnerabilidades conocidas",
+
+	// Información de versiones
+	"info.not_found":      "la versión %s no está publicada en el mirror ni instalada",
+	"info.date":           "Fecha de publicación",
+	"info.security":       "Versión de seguridad",
+	"info.npm":            "npm",
+	"info.v8":             "V8",
+	"info.uv":             "libuv",
+	"info.zlib":           "zlib",
+	"info.openssl":        "OpenSSL",
+	"info.modules":        "ABI de módulos nativos",
+	"info.files":          "Plataformas publicadas",
+	"info.available":      "Disponible para %s",
+	"info.installed":      "Instalada",
+	"info.current_marker": " (actual)",
+	"info.path":           "Ruta",
+	"info.size":           "Tamaño",
+	"info.aliases":        "Alias",
+	"info.abi":            "Compatibilidad de ABI",
+	"info.abi_same":       "igual que la versión actual (v%s); los módulos nativos son compatibles",
+	"info.abi_different":  "distinta de la versión actual (v%s); los módulos nativos se deben recompilar",
+	"info.yes":            "sí",
+	"info.no":             "no",
+
+	// Uso de versiones
+	"usage.read_error":     "Error al leer el archivo %s: %v",
+	"usage.decode_error":   "El archivo %s no es válido: %v",
+	"usage.write_error":    "Error al guardar la fecha de último uso: %v",
+	"usage.record_warning": "Advertencia: %v",
+
+	// Limpieza de versiones
+	"prune.no_policy":     "Indique una política: --keep <n>, --unused <duración> o ambas",
+	"prune.nothing":       "No hay versiones que eliminar\n",
+	"prune.dry_run_title": "Versiones que se eliminarían:\n",
+	"prune.title":         "Se eliminarán las siguientes versiones:\n",
+	"prune.candidate":     " - %s (último uso: %s, %s)\n",
+	"prune.total":         "Espacio a liberar: %s\n",
+	"prune.confirm":       "¿Desea eliminar estas versiones?",
+	"prune.cancelled":     "Limpieza cancelada\n",
+	"prune.done.one":      "Se eliminó %d versión (%s liberados)\n",
+	"prune.done.other":    "Se eliminaron %d versiones (%s liberados)\n",
+
+	// Archivos PAC
+	"pac.parse_error":            "Error al interpretar el archivo PAC: %v",
+	"pac.run_error":              "Error al ejecutar el archivo PAC: %v",
+	"pac.missing_function":       "El archivo PAC no define la función FindProxyForURL",
+	"pac.eval_error":             "Error al evaluar FindProxyForURL: %v",
+	"pac.unsupported_proxy_type": "Tipo de proxy no soportado: %s",
+	"pac.missing_proxy_host":     "Falta el host del proxy %s",
+	"pac.unclosed_comment":       "línea %d: comentario sin cerrar",
+	"pac.invalid_number":         "línea %d: número inválido %s",
+	"pac.line_error":             "línea %d: %v",
+	"pac.unexpected_char":        "línea %d: carácter inesperado %q",
+	"pac.unclosed_string":        "cadena sin cerrar",
+	"pac.expected":               "línea %d: se esperaba %q y se encontró %q",
+	"pac.expected_identifier":    "línea %d: se esperaba un identificador y se encontró %q",
+	"pac.expected_brace":         "línea %d: se esperaba \"}\"",
+	"pac.invalid_assignment":     "línea %d: asignación inválida",
+	"pac.unexpected_eof":         "línea %d: fin de archivo inesperado",
+	"pac.unexpected_token":       "línea %d: token inesperado %q",
+	"pac.unsupported_keyword":    "línea %d: la sentencia %s no está soportada en los archivos PAC",
+	"pac.unsupported_operator":   "línea %d: el operador %s no está soportado en los archivos PAC",
+	"pac.unsupported_object":     "línea %d: los objetos literales no están soportados en los archivos PAC",
+	"pac.unsupported_regexp":     "línea %d: las expresiones regulares no están soportadas en los archivos PAC",
+	"pac.step_limit":             "se superó el límite de ejecución del script",
+	"pac.not_a_function":         "el valor no es una función",
+	"pac.unsupported_statement":  "sentencia no soportada",
+	"pac.not_defined":            "%s no está definido",
+	"pac.unsupported_expression": "expresión no soportada",
+	"pac.array_index_assignment": "sólo se puede asignar por índice en arreglos",
+	"pac.invalid_index":          "índice inválido",
+	"pac.unsupported_assignment": "asignación no soportada",
+	"pac.undefined_property":     "no se puede leer la propiedad %s de undefined",
+	"pac.undefined_index":        "no se puede leer un índice de undefined",
+	"pac.undefined_method":       "no se puede llamar al método %s de undefined",
+	"pac.unsupported_method":     "método no soportado: %s",
+}
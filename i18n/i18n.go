@@ -0,0 +1,125 @@
+/*
+Package i18n contiene los catálogos de mensajes de polynode y selecciona el idioma en el que
+se muestran. Los mensajes se identifican con claves ("use.changed") y se formatean como en
+fmt.Sprintf:
+
+	fmt.Println(i18n.T("use.changed", version))
+	fmt.Println(i18n.N("cache.cleaned", removed, removed, size))
+
+Las claves con formas de plural se definen con los sufijos ".one" y ".other".
+*/
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DefaultLanguage es el idioma que se usa cuando no se indica ninguno y el del sistema no está disponible
+const DefaultLanguage = "es"
+
+// catalogs contiene los mensajes de cada idioma
+var catalogs = map[string]map[string]string{
+	"es": es,
+	"en": en,
+}
+
+var language = DefaultLanguage
+
+// Languages devuelve los idiomas disponibles
+func Languages() []string {
+	return []string{"es", "en"}
+}
+
+// Language devuelve el idioma seleccionado
+func Language() string {
+	return language
+}
+
+/*
+SetLanguage selecciona el idioma de los mensajes. Si lang está vacío, se usa el idioma del
+sistema según LC_ALL, LC_MESSAGES o LANG, y si no está disponible, DefaultLanguage.
+*/
+func SetLanguage(lang string) {
+	if lang == "" {
+		lang = systemLanguage()
+	}
+	if _, ok := catalogs[lang]; !ok {
+		lang = DefaultLanguage
+	}
+	language = lang
+}
+
+// systemLanguage devuelve el idioma de las variables de entorno de locale ("en_US.UTF-8" es "en")
+func systemLanguage() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		if value == "C" || value == "POSIX" {
+			return "en"
+		}
+		lang := strings.ToLower(value)
+		if i := strings.IndexAny(lang, "_.@-"); i >= 0 {
+			lang = lang[:i]
+		}
+		if _, ok := catalogs[lang]; ok {
+			return lang
+		}
+	}
+	return ""
+}
+
+// Has indica si existe un mensaje con la clave indicada
+func Has(key string) bool {
+	_, ok := lookup(key)
+	return ok
+}
+
+// T devuelve el mensaje de la clave en el idioma seleccionado, formateado con args
+func T(key string, args ...interface{}) string {
+	message, ok := lookup(key)
+	if !ok {
+		message = key
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// N devuelve la forma singular (".one") o plural (".other") del mensaje según count
+func N(key string, count int, args ...interface{}) string {
+	if count == 1 {
+		return T(key+".one", args...)
+	}
+	return T(key+".other", args...)
+}
+
+// Errorf crea un error con el mensaje de la clave; admite %w como fmt.Errorf
+func Errorf(key string, args ...interface{}) error {
+	message, ok := lookup(key)
+	if !ok {
+		message = key
+	}
+	return fmt.Errorf(message, args...)
+}
+
+// Nerrorf crea un error con la forma singular (".one") o plural (".other") del mensaje según count
+func Nerrorf(key string, count int, args ...interface{}) error {
+	if count == 1 {
+		return Errorf(key+".one", args...)
+	}
+	return Errorf(key+".other", args...)
+}
+
+// lookup busca la clave en el idioma seleccionado y, si no está traducida, en el idioma por defecto
+func lookup(key string) (string, bool) {
+	if message, ok := catalogs[language][key]; ok {
+		return message, true
+	}
+	message, ok := catalogs[DefaultLanguage][key]
+	return message, ok
+}
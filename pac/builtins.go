@@ -0,0 +1,400 @@
+package pac
+
+import (
+	"math"
+	"net"
+	"polynode/i18n"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var weekdays = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+var months = []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+
+// Resolver permite reemplazar la resolución de nombres y la IP local al evaluar el script
+type Resolver struct {
+	LookupHost  func(host string) ([]string, error)
+	MyIPAddress func() string
+	Now         func() time.Time
+}
+
+func defaultResolver() Resolver {
+	return Resolver{
+		LookupHost:  net.LookupHost,
+		MyIPAddress: localIPAddress,
+		Now:         time.Now,
+	}
+}
+
+// globals devuelve las funciones predefinidas disponibles para los archivos PAC
+func (r Resolver) globals() map[string]Value {
+	return map[string]Value{
+		"isPlainHostName": builtin(func(args []Value) (Value, error) {
+			return !strings.Contains(stringArg(args, 0), "."), nil
+		}),
+		"dnsDomainIs": builtin(func(args []Value) (Value, error) {
+			return strings.HasSuffix(strings.ToLower(stringArg(args, 0)), strings.ToLower(stringArg(args, 1))), nil
+		}),
+		"localHostOrDomainIs": builtin(func(args []Value) (Value, error) {
+			host := strings.ToLower(stringArg(args, 0))
+			hostDomain := strings.ToLower(stringArg(args, 1))
+			if host == hostDomain {
+				return true, nil
+			}
+			return !strings.Contains(host, ".") && strings.HasPrefix(hostDomain, host+"."), nil
+		}),
+		"dnsDomainLevels": builtin(func(args []Value) (Value, error) {
+			return float64(strings.Count(stringArg(args, 0), ".")), nil
+		}),
+		"isResolvable": builtin(func(args []Value) (Value, error) {
+			return r.resolve(stringArg(args, 0)) != "", nil
+		}),
+		"dnsResolve": builtin(func(args []Value) (Value, error) {
+			if ip := r.resolve(stringArg(args, 0)); ip != "" {
+				return ip, nil
+			}
+			return nil, nil
+		}),
+		"myIpAddress": builtin(func(args []Value) (Value, error) {
+			return r.MyIPAddress(), nil
+		}),
+		"isInNet": builtin(func(args []Value) (Value, error) {
+			ip := net.ParseIP(r.resolve(stringArg(args, 0))).To4()
+			pattern := net.ParseIP(stringArg(args, 1)).To4()
+			mask := net.ParseIP(stringArg(args, 2)).To4()
+			if ip == nil || pattern == nil || mask == nil {
+				return false, nil
+			}
+			network := net.IPNet{IP: pattern.Mask(net.IPMask(mask)), Mask: net.IPMask(mask)}
+			return network.Contains(ip), nil
+		}),
+		"convert_addr": builtin(func(args []Value) (Value, error) {
+			ip := net.ParseIP(stringArg(args, 0)).To4()
+			if ip == nil {
+				return float64(0), nil
+			}
+			return float64(uint32(ip[0])<<24 | uint32(ip[1])<<16 | uint32(ip[2])<<8 | uint32(ip[3])), nil
+		}),
+		"shExpMatch": builtin(func(args []Value) (Value, error) {
+			return shExpMatch(stringArg(args, 0), stringArg(args, 1)), nil
+		}),
+		"weekdayRange": builtin(func(args []Value) (Value, error) {
+			return r.weekdayRange(args), nil
+		}),
+		"dateRange": builtin(func(args []Value) (Value, error) {
+			return r.dateRange(args), nil
+		}),
+		"timeRange": builtin(func(args []Value) (Value, error) {
+			return r.timeRange(args), nil
+		}),
+		"alert": builtin(func(args []Value) (Value, error) {
+			return nil, nil
+		}),
+	}
+}
+
+func (r Resolver) resolve(host string) string {
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.String()
+	}
+	addrs, err := r.LookupHost(host)
+	if err != nil {
+		return ""
+	}
+	for _, addr := range addrs {
+		if ip := net.ParseIP(addr); ip != nil && ip.To4() != nil {
+			return ip.String()
+		}
+	}
+	if len(addrs) > 0 {
+		return addrs[0]
+	}
+	return ""
+}
+
+// localIPAddress devuelve la primera IPv4 no local de las interfaces de red
+func localIPAddress() string {
+	addrs, err := net.InterfaceAddrs()
+	if err == nil {
+		for _, addr := range addrs {
+			if network, ok := addr.(*net.IPNet); ok && !network.IP.IsLoopback() && network.IP.To4() != nil {
+				return network.IP.String()
+			}
+		}
+	}
+	return "127.0.0.1"
+}
+
+// shExpMatch compara una cadena con una expresión de shell que admite los comodines * y ?
+func shExpMatch(value, pattern string) bool {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for _, c := range pattern {
+		switch c {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+	matched, err := regexp.MatchString(sb.String(), value)
+	return err == nil && matched
+}
+
+// timeArgs separa el argumento opcional "GMT" del resto de los argumentos
+func (r Resolver) timeArgs(args []Value) ([]Value, time.Time) {
+	now := r.Now()
+	if len(args) > 0 && args[len(args)-1] == "GMT" {
+		return args[:len(args)-1], now.UTC()
+	}
+	return args, now
+}
+
+func (r Resolver) weekdayRange(args []Value) bool {
+	args, now := r.timeArgs(args)
+	if len(args) == 0 {
+		return false
+	}
+	from := indexOf(weekdays, stringArg(args, 0))
+	to := from
+	if len(args) > 1 {
+		to = indexOf(weekdays, stringArg(args, 1))
+	}
+	if from < 0 || to < 0 {
+		return false
+	}
+	return inRange(int(now.Weekday()), from, to)
+}
+
+func (r Resolver) timeRange(args []Value) bool {
+	args, now := r.timeArgs(args)
+	seconds := now.Hour()*3600 + now.Minute()*60 + now.Second()
+
+	values := make([]int, len(args))
+	for i := range args {
+		values[i] = int(toNumber(args[i]))
+	}
+
+	switch len(values) {
+	case 1:
+		return now.Hour() == values[0]
+	case 2:
+		return inRange(now.Hour(), values[0], values[1]-1)
+	case 4:
+		return inRange(seconds, values[0]*3600+values[1]*60, values[2]*3600+values[3]*60-1)
+	case 6:
+		return inRange(seconds, values[0]*3600+values[1]*60+values[2], values[3]*3600+values[4]*60+values[5])
+	}
+	return false
+}
+
+/*
+dateRange admite las formas con un solo tipo de valor: día del mes, mes o año,
+ya sea un valor único o un rango (por ejemplo dateRange(1, 15) o dateRange("JAN", "MAR")).
+*/
+func (r Resolver) dateRange(args []Value) bool {
+	args, now := r.timeArgs(args)
+	if len(args) != 1 && len(args) != 2 {
+		return false
+	}
+
+	current := func(v Value) (int, int) {
+		if month := indexOf(months, toString(v)); month >= 0 {
+			return int(now.Month()) - 1, month
+		}
+		n := int(toNumber(v))
+		if n > 31 {
+			return now.Year(), n
+		}
+		return now.Day(), n
+	}
+
+	value, from := current(args[0])
+	to := from
+	if len(args) == 2 {
+		_, to = current(args[1])
+	}
+	return inRange(value, from, to)
+}
+
+// inRange indica si value está entre from y to, considerando rangos que dan la vuelta (por ejemplo FRI a MON)
+func inRange(value, from, to int) bool {
+	if from <= to {
+		return value >= from && value <= to
+	}
+	return value >= from || value <= to
+}
+
+func indexOf(list []string, value string) int {
+	for i, item := range list {
+		if item == strings.ToUpper(value) {
+			return i
+		}
+	}
+	return -1
+}
+
+func stringArg(args []Value, i int) string {
+	if i >= len(args) || args[i] == nil {
+		return ""
+	}
+	return toString(args[i])
+}
+
+func property(obj Value, name string) (Value, error) {
+	switch o := obj.(type) {
+	case string:
+		if name == "length" {
+			return float64(len(o)), nil
+		}
+	case *array:
+		if name == "length" {
+			return float64(len(o.elems)), nil
+		}
+	case nil:
+		return nil, i18n.Errorf("pac.undefined_property", name)
+	}
+	return nil, nil
+}
+
+func indexValue(obj Value, index Value) (Value, error) {
+	i := int(toNumber(index))
+	switch o := obj.(type) {
+	case *array:
+		if i >= 0 && i < len(o.elems) {
+			return o.elems[i], nil
+		}
+		return nil, nil
+	case string:
+		if i >= 0 && i < len(o) {
+			return o[i : i+1], nil
+		}
+		return nil, nil
+	case nil:
+		return nil, i18n.Errorf("pac.undefined_index")
+	}
+	return nil, nil
+}
+
+// callMethod ejecuta los métodos de cadenas y arreglos más utilizados en archivos PAC
+func callMethod(obj Value, name string, args []Value) (Value, error) {
+	switch o := obj.(type) {
+	case string:
+		return callStringMethod(o, name, args)
+	case *array:
+		switch name {
+		case "indexOf":
+			for i, elem := range o.elems {
+				if strictEquals(elem, argOrNil(args, 0)) {
+					return float64(i), nil
+				}
+			}
+			return float64(-1), nil
+		case "push":
+			o.elems = append(o.elems, args...)
+			return float64(len(o.elems)), nil
+		case "join":
+			separator := ","
+			if len(args) > 0 {
+				separator = stringArg(args, 0)
+			}
+			parts := make([]string, len(o.elems))
+			for i, elem := range o.elems {
+				if elem != nil {
+					parts[i] = toString(elem)
+				}
+			}
+			return strings.Join(parts, separator), nil
+		}
+	case nil:
+		return nil, i18n.Errorf("pac.undefined_method", name)
+	}
+	return nil, i18n.Errorf("pac.unsupported_method", name)
+}
+
+func callStringMethod(s, name string, args []Value) (Value, error) {
+	switch name {
+	case "toLowerCase":
+		return strings.ToLower(s), nil
+	case "toUpperCase":
+		return strings.ToUpper(s), nil
+	case "trim":
+		return strings.TrimSpace(s), nil
+	case "indexOf":
+		return float64(strings.Index(s, stringArg(args, 0))), nil
+	case "lastIndexOf":
+		return float64(strings.LastIndex(s, stringArg(args, 0))), nil
+	case "startsWith":
+		return strings.HasPrefix(s, stringArg(args, 0)), nil
+	case "endsWith":
+		return strings.HasSuffix(s, stringArg(args, 0)), nil
+	case "charAt":
+		i := int(toNumber(argOrNil(args, 0)))
+		if len(args) == 0 {
+			i = 0
+		}
+		if i >= 0 && i < len(s) {
+			return s[i : i+1], nil
+		}
+		return "", nil
+	case "substring":
+		start := clamp(numberArg(args, 0, 0), len(s))
+		end := clamp(numberArg(args, 1, float64(len(s))), len(s))
+		if start > end {
+			start, end = end, start
+		}
+		return s[start:end], nil
+	case "substr":
+		start := int(numberArg(args, 0, 0))
+		if start < 0 {
+			start = len(s) + start
+		}
+		start = clamp(float64(start), len(s))
+		end := clamp(float64(start)+numberArg(args, 1, float64(len(s))), len(s))
+		return s[start:end], nil
+	case "split":
+		if len(args) == 0 {
+			return &array{elems: []Value{s}}, nil
+		}
+		arr := &array{}
+		for _, part := range strings.Split(s, stringArg(args, 0)) {
+			arr.elems = append(arr.elems, part)
+		}
+		return arr, nil
+	case "replace":
+		return strings.Replace(s, stringArg(args, 0), stringArg(args, 1), 1), nil
+	}
+	return nil, i18n.Errorf("pac.unsupported_method", name)
+}
+
+func argOrNil(args []Value, i int) Value {
+	if i < len(args) {
+		return args[i]
+	}
+	return nil
+}
+
+func numberArg(args []Value, i int, fallback float64) float64 {
+	if i >= len(args) || args[i] == nil {
+		return fallback
+	}
+	n := toNumber(args[i])
+	if math.IsNaN(n) {
+		return 0
+	}
+	return n
+}
+
+func clamp(value float64, length int) int {
+	if value < 0 {
+		return 0
+	}
+	if value > float64(length) {
+		return length
+	}
+	return int(value)
+}
@@ -0,0 +1,593 @@
+package pac
+
+import (
+	"math"
+	"polynode/i18n"
+	"strconv"
+	"strings"
+)
+
+/*
+Value es un valor de JavaScript: nil (undefined o null), bool, float64, string,
+*array, *function o builtin.
+*/
+type Value interface{}
+
+type array struct {
+	elems []Value
+}
+
+type function struct {
+	params  []string
+	body    []stmt
+	closure *env
+}
+
+type builtin func(args []Value) (Value, error)
+
+type env struct {
+	vars   map[string]Value
+	parent *env
+}
+
+// Cantidad máxima de pasos de ejecución por llamada, para cortar ciclos infinitos
+const maxSteps = 1000000
+
+type control int
+
+const (
+	controlNone control = iota
+	controlReturn
+	controlBreak
+	controlContinue
+)
+
+type interpreter struct {
+	global *env
+	steps  int
+}
+
+func newEnv(parent *env) *env {
+	return &env{vars: map[string]Value{}, parent: parent}
+}
+
+func (e *env) lookup(name string) (*env, bool) {
+	for scope := e; scope != nil; scope = scope.parent {
+		if _, ok := scope.vars[name]; ok {
+			return scope, true
+		}
+	}
+	return nil, false
+}
+
+func (in *interpreter) tick() error {
+	in.steps++
+	if in.steps > maxSteps {
+		return i18n.Errorf("pac.step_limit")
+	}
+	return nil
+}
+
+func (in *interpreter) run(program []stmt) error {
+	_, _, err := in.execBlock(program, in.global)
+	return err
+}
+
+func (in *interpreter) call(fn Value, args []Value) (Value, error) {
+	switch f := fn.(type) {
+	case builtin:
+		return f(args)
+	case *function:
+		scope := newEnv(f.closure)
+		for i, param := range f.params {
+			var arg Value
+			if i < len(args) {
+				arg = args[i]
+			}
+			scope.vars[param] = arg
+		}
+		ctrl, value, err := in.execBlock(f.body, scope)
+		if err != nil {
+			return nil, err
+		}
+		if ctrl == controlReturn {
+			return value, nil
+		}
+		return nil, nil
+	}
+	return nil, i18n.Errorf("pac.not_a_function")
+}
+
+func (in *interpreter) execBlock(stmts []stmt, scope *env) (control, Value, error) {
+	// Las declaraciones de funciones se registran antes de ejecutar el bloque (hoisting)
+	for _, s := range stmts {
+		if decl, ok := s.(funcDecl); ok {
+			scope.vars[decl.name] = &function{params: decl.params, body: decl.body, closure: scope}
+		}
+	}
+
+	for _, s := range stmts {
+		ctrl, value, err := in.exec(s, scope)
+		if err != nil || ctrl != controlNone {
+			return ctrl, value, err
+		}
+	}
+	return controlNone, nil, nil
+}
+
+func (in *interpreter) exec(s stmt, scope *env) (control, Value, error) {
+	if err := in.tick(); err != nil {
+		return controlNone, nil, err
+	}
+
+	switch s := s.(type) {
+	case funcDecl:
+		return controlNone, nil, nil
+
+	case varDecl:
+		for i, name := range s.names {
+			var value Value
+			if s.inits[i] != nil {
+				var err error
+				if value, err = in.eval(s.inits[i], scope); err != nil {
+					return controlNone, nil, err
+				}
+			}
+			scope.vars[name] = value
+		}
+		return controlNone, nil, nil
+
+	case exprStmt:
+		_, err := in.eval(s.x, scope)
+		return controlNone, nil, err
+
+	case blockStmt:
+		return in.execBlock(s.stmts, scope)
+
+	case ifStmt:
+		cond, err := in.eval(s.cond, scope)
+		if err != nil {
+			return controlNone, nil, err
+		}
+		if truthy(cond) {
+			return in.exec(s.then, scope)
+		}
+		if s.els != nil {
+			return in.exec(s.els, scope)
+		}
+		return controlNone, nil, nil
+
+	case returnStmt:
+		if s.value == nil {
+			return controlReturn, nil, nil
+		}
+		value, err := in.eval(s.value, scope)
+		return controlReturn, value, err
+
+	case breakStmt:
+		return controlBreak, nil, nil
+
+	case continueStmt:
+		return controlContinue, nil, nil
+
+	case whileStmt:
+		return in.loop(s.cond, nil, s.body, scope)
+
+	case forStmt:
+		if s.init != nil {
+			if _, _, err := in.exec(s.init, scope); err != nil {
+				return controlNone, nil, err
+			}
+		}
+		return in.loop(s.cond, s.update, s.body, scope)
+	}
+
+	return controlNone, nil, i18n.Errorf("pac.unsupported_statement")
+}
+
+func (in *interpreter) loop(cond, update expr, body stmt, scope *env) (control, Value, error) {
+	for {
+		if err := in.tick(); err != nil {
+			return controlNone, nil, err
+		}
+		if cond != nil {
+			value, err := in.eval(cond, scope)
+			if err != nil {
+				return controlNone, nil, err
+			}
+			if !truthy(value) {
+				return controlNone, nil, nil
+			}
+		}
+
+		ctrl, value, err := in.exec(body, scope)
+		if err != nil {
+			return controlNone, nil, err
+		}
+		if ctrl == controlReturn {
+			return ctrl, value, nil
+		}
+		if ctrl == controlBreak {
+			return controlNone, nil, nil
+		}
+
+		if update != nil {
+			if _, err := in.eval(update, scope); err != nil {
+				return controlNone, nil, err
+			}
+		}
+	}
+}
+
+func (in *interpreter) eval(x expr, scope *env) (Value, error) {
+	if err := in.tick(); err != nil {
+		return nil, err
+	}
+
+	switch x := x.(type) {
+	case literal:
+		return x.value, nil
+
+	case ident:
+		if owner, ok := scope.lookup(x.name); ok {
+			return owner.vars[x.name], nil
+		}
+		return nil, i18n.Errorf("pac.not_defined", x.name)
+
+	case arrayLit:
+		arr := &array{}
+		for _, elem := range x.elems {
+			value, err := in.eval(elem, scope)
+			if err != nil {
+				return nil, err
+			}
+			arr.elems = append(arr.elems, value)
+		}
+		return arr, nil
+
+	case funcExpr:
+		return &function{params: x.params, body: x.body, closure: scope}, nil
+
+	case unaryExpr:
+		value, err := in.eval(x.x, scope)
+		if err != nil {
+			return nil, err
+		}
+		switch x.op {
+		case "!":
+			return !truthy(value), nil
+		case "-":
+			return -toNumber(value), nil
+		case "+":
+			return toNumber(value), nil
+		case "typeof":
+			return typeOf(value), nil
+		}
+
+	case binaryExpr:
+		return in.evalBinary(x, scope)
+
+	case conditionalExpr:
+		cond, err := in.eval(x.cond, scope)
+		if err != nil {
+			return nil, err
+		}
+		if truthy(cond) {
+			return in.eval(x.a, scope)
+		}
+		return in.eval(x.b, scope)
+
+	case assignExpr:
+		value, err := in.eval(x.value, scope)
+		if err != nil {
+			return nil, err
+		}
+		if x.op != "=" {
+			current, err := in.eval(x.target, scope)
+			if err != nil {
+				return nil, err
+			}
+			value = arithmetic(strings.TrimSuffix(x.op, "="), current, value)
+		}
+		return value, in.assign(x.target, value, scope)
+
+	case updateExpr:
+		current, err := in.eval(x.target, scope)
+		if err != nil {
+			return nil, err
+		}
+		old := toNumber(current)
+		updated := old + 1
+		if x.op == "--" {
+			updated = old - 1
+		}
+		if err := in.assign(x.target, updated, scope); err != nil {
+			return nil, err
+		}
+		if x.prefix {
+			return updated, nil
+		}
+		return old, nil
+
+	case memberExpr:
+		obj, err := in.eval(x.obj, scope)
+		if err != nil {
+			return nil, err
+		}
+		return property(obj, x.name)
+
+	case indexExpr:
+		obj, err := in.eval(x.obj, scope)
+		if err != nil {
+			return nil, err
+		}
+		index, err := in.eval(x.index, scope)
+		if err != nil {
+			return nil, err
+		}
+		return indexValue(obj, index)
+
+	case callExpr:
+		args := make([]Value, 0, len(x.args))
+		for _, arg := range x.args {
+			value, err := in.eval(arg, scope)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, value)
+		}
+
+		// Las llamadas a métodos de cadenas y arreglos se resuelven directamente
+		if member, ok := x.callee.(memberExpr); ok {
+			obj, err := in.eval(member.obj, scope)
+			if err != nil {
+				return nil, err
+			}
+			return callMethod(obj, member.name, args)
+		}
+
+		fn, err := in.eval(x.callee, scope)
+		if err != nil {
+			return nil, err
+		}
+		return in.call(fn, args)
+	}
+
+	return nil, i18n.Errorf("pac.unsupported_expression")
+}
+
+func (in *interpreter) evalBinary(x binaryExpr, scope *env) (Value, error) {
+	left, err := in.eval(x.l, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	// Los operadores lógicos evalúan el lado derecho sólo si es necesario
+	switch x.op {
+	case "&&":
+		if !truthy(left) {
+			return left, nil
+		}
+		return in.eval(x.r, scope)
+	case "||":
+		if truthy(left) {
+			return left, nil
+		}
+		return in.eval(x.r, scope)
+	}
+
+	right, err := in.eval(x.r, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	switch x.op {
+	case ",":
+		return right, nil
+	case "==":
+		return looseEquals(left, right), nil
+	case "!=":
+		return !looseEquals(left, right), nil
+	case "===":
+		return strictEquals(left, right), nil
+	case "!==":
+		return !strictEquals(left, right), nil
+	case "<", ">", "<=", ">=":
+		return compare(x.op, left, right), nil
+	}
+	return arithmetic(x.op, left, right), nil
+}
+
+func (in *interpreter) assign(target expr, value Value, scope *env) error {
+	switch t := target.(type) {
+	case ident:
+		if owner, ok := scope.lookup(t.name); ok {
+			owner.vars[t.name] = value
+		} else {
+			in.global.vars[t.name] = value
+		}
+		return nil
+	case indexExpr:
+		obj, err := in.eval(t.obj, scope)
+		if err != nil {
+			return err
+		}
+		index, err := in.eval(t.index, scope)
+		if err != nil {
+			return err
+		}
+		arr, ok := obj.(*array)
+		if !ok {
+			return i18n.Errorf("pac.array_index_assignment")
+		}
+		i := int(toNumber(index))
+		if i < 0 {
+			return i18n.Errorf("pac.invalid_index")
+		}
+		for len(arr.elems) <= i {
+			arr.elems = append(arr.elems, nil)
+		}
+		arr.elems[i] = value
+		return nil
+	}
+	return i18n.Errorf("pac.unsupported_assignment")
+}
+
+func truthy(v Value) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0 && !math.IsNaN(v)
+	case string:
+		return v != ""
+	}
+	return true
+}
+
+func toNumber(v Value) float64 {
+	switch v := v.(type) {
+	case nil:
+		return math.NaN()
+	case bool:
+		if v {
+			return 1
+		}
+		return 0
+	case float64:
+		return v
+	case string:
+		s := strings.TrimSpace(v)
+		if s == "" {
+			return 0
+		}
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return math.NaN()
+		}
+		return n
+	}
+	return math.NaN()
+}
+
+func toString(v Value) string {
+	switch v := v.(type) {
+	case nil:
+		return "undefined"
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1e21 {
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case string:
+		return v
+	case *array:
+		parts := make([]string, len(v.elems))
+		for i, elem := range v.elems {
+			if elem != nil {
+				parts[i] = toString(elem)
+			}
+		}
+		return strings.Join(parts, ",")
+	}
+	return "function"
+}
+
+func typeOf(v Value) string {
+	switch v.(type) {
+	case nil:
+		return "undefined"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case *array:
+		return "object"
+	}
+	return "function"
+}
+
+func strictEquals(a, b Value) bool {
+	switch a := a.(type) {
+	case nil:
+		return b == nil
+	case bool, float64, string:
+		return a == b
+	case *array:
+		other, ok := b.(*array)
+		return ok && a == other
+	case *function:
+		other, ok := b.(*function)
+		return ok && a == other
+	}
+	return false
+}
+
+func looseEquals(a, b Value) bool {
+	if typeOf(a) == typeOf(b) {
+		return strictEquals(a, b)
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return toNumber(a) == toNumber(b)
+}
+
+func compare(op string, a, b Value) bool {
+	as, aIsString := a.(string)
+	bs, bIsString := b.(string)
+	if aIsString && bIsString {
+		switch op {
+		case "<":
+			return as < bs
+		case ">":
+			return as > bs
+		case "<=":
+			return as <= bs
+		}
+		return as >= bs
+	}
+
+	an, bn := toNumber(a), toNumber(b)
+	switch op {
+	case "<":
+		return an < bn
+	case ">":
+		return an > bn
+	case "<=":
+		return an <= bn
+	}
+	return an >= bn
+}
+
+func arithmetic(op string, a, b Value) Value {
+	if op == "+" {
+		_, aIsString := a.(string)
+		_, bIsString := b.(string)
+		_, aIsArray := a.(*array)
+		_, bIsArray := b.(*array)
+		if aIsString || bIsString || aIsArray || bIsArray {
+			return toString(a) + toString(b)
+		}
+		return toNumber(a) + toNumber(b)
+	}
+
+	an, bn := toNumber(a), toNumber(b)
+	switch op {
+	case "-":
+		return an - bn
+	case "*":
+		return an * bn
+	case "/":
+		return an / bn
+	case "%":
+		return math.Mod(an, bn)
+	}
+	return math.NaN()
+}
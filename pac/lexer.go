@@ -0,0 +1,200 @@
+package pac
+
+import (
+	"polynode/i18n"
+	"strconv"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenPunct
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	num  float64
+	line int
+}
+
+// Operadores de varios caracteres, ordenados de mayor a menor longitud
+var punctuators = []string{
+	"===", "!==",
+	"==", "!=", "<=", ">=", "&&", "||", "++", "--", "+=", "-=",
+	"{", "}", "(", ")", "[", "]", ";", ",", ".", "?", ":",
+	"=", "<", ">", "+", "-", "*", "/", "%", "!",
+}
+
+/*
+tokenize divide el código de un archivo PAC en tokens.
+Soporta comentarios de línea y de bloque, cadenas con comillas simples o dobles
+y números decimales o hexadecimales. Las expresiones regulares no están soportadas:
+una "/" donde se espera un valor se informa como tal en lugar de como un error de sintaxis.
+*/
+func tokenize(src string) ([]token, error) {
+	var tokens []token
+	line := 1
+	i := 0
+
+	for i < len(src) {
+		c := src[i]
+
+		switch {
+		case c == '\n':
+			line++
+			i++
+			continue
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+			continue
+		case strings.HasPrefix(src[i:], "//"):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			continue
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, i18n.Errorf("pac.unclosed_comment", line)
+			}
+			line += strings.Count(src[i:i+2+end], "\n")
+			i += end + 4
+			continue
+		case c == '/' && startsOperand(tokens):
+			return nil, i18n.Errorf("pac.unsupported_regexp", line)
+		}
+
+		if isIdentStart(c) {
+			start := i
+			for i < len(src) && isIdentPart(src[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: src[start:i], line: line})
+			continue
+		}
+
+		if isDigit(c) || (c == '.' && i+1 < len(src) && isDigit(src[i+1])) {
+			start := i
+			if strings.HasPrefix(src[i:], "0x") || strings.HasPrefix(src[i:], "0X") {
+				i += 2
+				for i < len(src) && isHexDigit(src[i]) {
+					i++
+				}
+				value, err := strconv.ParseInt(src[start+2:i], 16, 64)
+				if err != nil {
+					return nil, i18n.Errorf("pac.invalid_number", line, src[start:i])
+				}
+				tokens = append(tokens, token{kind: tokenNumber, text: src[start:i], num: float64(value), line: line})
+				continue
+			}
+			for i < len(src) && (isDigit(src[i]) || src[i] == '.') {
+				i++
+			}
+			value, err := strconv.ParseFloat(src[start:i], 64)
+			if err != nil {
+				return nil, i18n.Errorf("pac.invalid_number", line, src[start:i])
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: src[start:i], num: value, line: line})
+			continue
+		}
+
+		if c == '"' || c == '\'' {
+			value, length, err := readString(src[i:])
+			if err != nil {
+				return nil, i18n.Errorf("pac.line_error", line, err)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: value, line: line})
+			i += length
+			continue
+		}
+
+		matched := false
+		for _, punct := range punctuators {
+			if strings.HasPrefix(src[i:], punct) {
+				tokens = append(tokens, token{kind: tokenPunct, text: punct, line: line})
+				i += len(punct)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return nil, i18n.Errorf("pac.unexpected_char", line, c)
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokenEOF, line: line})
+	return tokens, nil
+}
+
+// startsOperand indica si el siguiente token ocupa el lugar de un valor (y no el de un operador)
+func startsOperand(tokens []token) bool {
+	if len(tokens) == 0 {
+		return true
+	}
+	last := tokens[len(tokens)-1]
+	switch last.kind {
+	case tokenNumber, tokenString:
+		return false
+	case tokenIdent:
+		return last.text == "return" || last.text == "typeof"
+	}
+	switch last.text {
+	case ")", "]", "++", "--":
+		return false
+	}
+	return true
+}
+
+// readString lee una cadena entre comillas y devuelve su valor y la cantidad de bytes consumidos
+func readString(src string) (string, int, error) {
+	quote := src[0]
+	var sb strings.Builder
+
+	for i := 1; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == quote:
+			return sb.String(), i + 1, nil
+		case c == '\n':
+			return "", 0, i18n.Errorf("pac.unclosed_string")
+		case c == '\\' && i+1 < len(src):
+			i++
+			switch src[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case 'r':
+				sb.WriteByte('\r')
+			default:
+				sb.WriteByte(src[i])
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	return "", 0, i18n.Errorf("pac.unclosed_string")
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || isDigit(c)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isHexDigit(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
@@ -0,0 +1,114 @@
+/*
+Package pac evalúa archivos de configuración automática de proxy (PAC).
+
+Incluye un intérprete para el subconjunto de JavaScript que se usa habitualmente en
+estos archivos (funciones, variables, condiciones, ciclos, cadenas y arreglos) junto
+con las funciones predefinidas del estándar PAC (dnsDomainIs, shExpMatch, isInNet, etc.).
+Las construcciones no soportadas (do…while, switch, try…catch, expresiones regulares,
+objetos literales y new) se rechazan al interpretar el archivo, indicando la línea.
+*/
+package pac
+
+import (
+	"net/url"
+	"polynode/i18n"
+	"strings"
+	"sync"
+)
+
+// Script es un archivo PAC interpretado, listo para evaluar FindProxyForURL
+type Script struct {
+	mu sync.Mutex
+	in *interpreter
+}
+
+// Proxy es una de las alternativas devueltas por FindProxyForURL
+type Proxy struct {
+	Type string
+	Host string
+}
+
+func Parse(src string) (*Script, error) {
+	return ParseWithResolver(src, defaultResolver())
+}
+
+// ParseWithResolver interpreta el script usando el resolver indicado para las funciones de red y fecha
+func ParseWithResolver(src string, resolver Resolver) (*Script, error) {
+	program, err := parse(src)
+	if err != nil {
+		return nil, i18n.Errorf("pac.parse_error", err)
+	}
+
+	global := newEnv(nil)
+	for name, value := range resolver.globals() {
+		global.vars[name] = value
+	}
+
+	in := &interpreter{global: global}
+	if err := in.run(program); err != nil {
+		return nil, i18n.Errorf("pac.run_error", err)
+	}
+
+	if _, ok := global.vars["FindProxyForURL"].(*function); !ok {
+		return nil, i18n.Errorf("pac.missing_function")
+	}
+
+	return &Script{in: in}, nil
+}
+
+// FindProxyForURL ejecuta la función del mismo nombre del archivo PAC y devuelve su resultado
+func (s *Script) FindProxyForURL(rawURL, host string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.in.steps = 0
+	result, err := s.in.call(s.in.global.vars["FindProxyForURL"], []Value{rawURL, host})
+	if err != nil {
+		return "", i18n.Errorf("pac.eval_error", err)
+	}
+	if result == nil {
+		return "DIRECT", nil
+	}
+	return toString(result), nil
+}
+
+// ParseResult interpreta un resultado como "PROXY proxy:8080; DIRECT" en la lista de alternativas
+func ParseResult(result string) []Proxy {
+	var proxies []Proxy
+	for _, entry := range strings.Split(result, ";") {
+		fields := strings.Fields(entry)
+		if len(fields) == 0 {
+			continue
+		}
+		proxy := Proxy{Type: strings.ToUpper(fields[0])}
+		if len(fields) > 1 {
+			proxy.Host = fields[1]
+		}
+		proxies = append(proxies, proxy)
+	}
+	if len(proxies) == 0 {
+		proxies = append(proxies, Proxy{Type: "DIRECT"})
+	}
+	return proxies
+}
+
+// URL devuelve la URL del proxy para usar en un http.Transport, o nil si la conexión es directa
+func (p Proxy) URL() (*url.URL, error) {
+	scheme := ""
+	switch p.Type {
+	case "DIRECT":
+		return nil, nil
+	case "PROXY", "HTTP":
+		scheme = "http"
+	case "HTTPS":
+		scheme = "https"
+	case "SOCKS", "SOCKS5":
+		scheme = "socks5"
+	default:
+		return nil, i18n.Errorf("pac.unsupported_proxy_type", p.Type)
+	}
+	if p.Host == "" {
+		return nil, i18n.Errorf("pac.missing_proxy_host", p.Type)
+	}
+	return &url.URL{Scheme: scheme, Host: p.Host}, nil
+}
@@ -0,0 +1,255 @@
+package pac
+
+import (
+	"errors"
+	"polynode/i18n"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testResolver resuelve nombres sin acceder a la red y fija la fecha en el viernes 15/03/2024 14:30:15 UTC
+func testResolver() Resolver {
+	hosts := map[string][]string{
+		"intranet.example.com": {"10.1.2.3"},
+		"www.example.com":      {"2001:db8::1", "93.184.216.34"},
+	}
+	return Resolver{
+		LookupHost: func(host string) ([]string, error) {
+			if addrs, ok := hosts[host]; ok {
+				return addrs, nil
+			}
+			return nil, errors.New("host desconocido")
+		},
+		MyIPAddress: func() string { return "192.168.1.10" },
+		Now: func() time.Time {
+			return time.Date(2024, time.March, 15, 14, 30, 15, 0, time.UTC)
+		},
+	}
+}
+
+// evalExpr evalúa una expresión dentro de FindProxyForURL y devuelve su valor como cadena
+func evalExpr(t *testing.T, expression string) string {
+	t.Helper()
+	script, err := ParseWithResolver(`function FindProxyForURL(url, host) { return "" + (`+expression+`); }`, testResolver())
+	if err != nil {
+		t.Fatalf("%s: %v", expression, err)
+	}
+	result, err := script.FindProxyForURL("http://www.example.com/", "www.example.com")
+	if err != nil {
+		t.Fatalf("%s: %v", expression, err)
+	}
+	return result
+}
+
+func TestBuiltins(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       string
+	}{
+		{`isPlainHostName("intranet")`, "true"},
+		{`isPlainHostName("intranet.example.com")`, "false"},
+		{`dnsDomainIs("www.Example.com", ".example.com")`, "true"},
+		{`dnsDomainIs("www.example.org", ".example.com")`, "false"},
+		{`localHostOrDomainIs("www", "www.example.com")`, "true"},
+		{`localHostOrDomainIs("www.example.com", "www.example.com")`, "true"},
+		{`localHostOrDomainIs("www.example.org", "www.example.com")`, "false"},
+		{`dnsDomainLevels("www.example.com")`, "2"},
+		{`dnsDomainLevels("www")`, "0"},
+		{`isResolvable("intranet.example.com")`, "true"},
+		{`isResolvable("desconocido.example.com")`, "false"},
+		{`dnsResolve("www.example.com")`, "93.184.216.34"},
+		{`dnsResolve("desconocido.example.com")`, "undefined"},
+		{`myIpAddress()`, "192.168.1.10"},
+		{`isInNet("intranet.example.com", "10.0.0.0", "255.0.0.0")`, "true"},
+		{`isInNet("10.1.2.3", "10.2.0.0", "255.255.0.0")`, "false"},
+		{`isInNet("desconocido.example.com", "10.0.0.0", "255.0.0.0")`, "false"},
+		{`convert_addr("10.1.2.3")`, "167838211"},
+		{`convert_addr("no es una ip")`, "0"},
+		{`shExpMatch("http://www.example.com/dist/", "*example.com/*")`, "true"},
+		{`shExpMatch("www.example.com", "ww?.example.com")`, "true"},
+		{`shExpMatch("www.example.com", "*.example.org")`, "false"},
+		{`shExpMatch("a.b", "a?b")`, "true"},
+		{`shExpMatch("axb", "a.b")`, "false"},
+		{`weekdayRange("FRI")`, "true"},
+		{`weekdayRange("MON", "FRI")`, "true"},
+		{`weekdayRange("SAT", "SUN")`, "false"},
+		{`weekdayRange("THU", "MON")`, "true"},
+		{`weekdayRange("FRI", "GMT")`, "true"},
+		{`dateRange(15)`, "true"},
+		{`dateRange(1, 10)`, "false"},
+		{`dateRange("MAR")`, "true"},
+		{`dateRange("JAN", "FEB")`, "false"},
+		{`dateRange(2020, 2025)`, "true"},
+		{`timeRange(14)`, "true"},
+		{`timeRange(9, 17)`, "true"},
+		{`timeRange(15, 17)`, "false"},
+		{`timeRange(14, 0, 14, 45)`, "true"},
+		{`timeRange(14, 30, 0, 14, 30, 10)`, "false"},
+		{`timeRange(14, 30, 0, 14, 30, 30, "GMT")`, "true"},
+		{`alert("mensaje")`, "undefined"},
+	}
+
+	for _, test := range tests {
+		if got := evalExpr(t, test.expression); got != test.want {
+			t.Errorf("%s = %s, se esperaba %s", test.expression, got, test.want)
+		}
+	}
+}
+
+func TestLanguage(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   string
+	}{
+		{
+			name: "ciclos, arreglos y métodos de cadenas",
+			script: `
+				var hosts = ["a.example.com", "b.example.com"];
+				function FindProxyForURL(url, host) {
+					var result = [];
+					for (var i = 0; i < hosts.length; i++) {
+						if (hosts[i].indexOf(host.split(".")[0]) === 0) { continue; }
+						result.push(hosts[i].toUpperCase());
+					}
+					var n = 0;
+					while (true) { if (++n >= 3) break; }
+					return result.join(";") + " " + n + " " + typeof host;
+				}`,
+			want: "A.EXAMPLE.COM;B.EXAMPLE.COM 3 string",
+		},
+		{
+			name: "funciones anidadas y operador condicional",
+			script: `
+				function FindProxyForURL(url, host) {
+					var pick = function(direct) { return direct ? "DIRECT" : "PROXY proxy:8080"; };
+					return pick(isPlainHostName(host)) + "; " + pick(url.substring(0, 5) == "https");
+				}`,
+			want: "PROXY proxy:8080; PROXY proxy:8080",
+		},
+		{
+			name:   "sin valor devuelto",
+			script: `function FindProxyForURL(url, host) { }`,
+			want:   "DIRECT",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			script, err := ParseWithResolver(test.script, testResolver())
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := script.FindProxyForURL("http://www.example.com/", "www.example.com")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("se obtuvo %q, se esperaba %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestUnsupportedSyntax(t *testing.T) {
+	i18n.SetLanguage("es")
+
+	tests := []struct {
+		name   string
+		script string
+		want   string
+	}{
+		{
+			name:   "do while",
+			script: "function FindProxyForURL(url, host) {\n var i = 0;\n do { i++; } while (i < 3);\n return \"DIRECT\";\n}",
+			want:   "línea 3: la sentencia do…while no está soportada",
+		},
+		{
+			name:   "switch",
+			script: "function FindProxyForURL(url, host) {\n switch (host) { case \"a\": return \"DIRECT\"; }\n}",
+			want:   "línea 2: la sentencia switch no está soportada",
+		},
+		{
+			name:   "try catch",
+			script: "function FindProxyForURL(url, host) {\n try { return \"DIRECT\"; } catch (e) { }\n}",
+			want:   "línea 2: la sentencia try…catch no está soportada",
+		},
+		{
+			name:   "throw",
+			script: "function FindProxyForURL(url, host) { throw \"error\"; }",
+			want:   "línea 1: la sentencia throw no está soportada",
+		},
+		{
+			name:   "expresión regular",
+			script: "function FindProxyForURL(url, host) {\n if (/^intranet\\./.test(host)) return \"DIRECT\";\n}",
+			want:   "línea 2: las expresiones regulares no están soportadas",
+		},
+		{
+			name:   "expresión regular asignada",
+			script: "var re = /[a-z]+\\.example\\.com$/i;\nfunction FindProxyForURL(url, host) { return \"DIRECT\"; }",
+			want:   "línea 1: las expresiones regulares no están soportadas",
+		},
+		{
+			name:   "objeto literal",
+			script: "var proxies = { a: \"PROXY a:8080\" };\nfunction FindProxyForURL(url, host) { return proxies.a; }",
+			want:   "línea 1: los objetos literales no están soportados",
+		},
+		{
+			name:   "new",
+			script: "function FindProxyForURL(url, host) { var d = new Date(); return \"DIRECT\"; }",
+			want:   "línea 1: el operador new no está soportado",
+		},
+		{
+			name:   "sin FindProxyForURL",
+			script: "function other() { return \"DIRECT\"; }",
+			want:   "no define la función FindProxyForURL",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseWithResolver(test.script, testResolver())
+			if err == nil {
+				t.Fatal("se esperaba un error al interpretar el archivo")
+			}
+			if !strings.Contains(err.Error(), test.want) {
+				t.Errorf("error %q, se esperaba que incluyera %q", err, test.want)
+			}
+		})
+	}
+}
+
+func TestDivisionIsNotRegexp(t *testing.T) {
+	if got := evalExpr(t, `(10 / 2) + [8][0] / 4 + "a/b".length / 3`); got != "8" {
+		t.Errorf("se obtuvo %s, se esperaba 8", got)
+	}
+}
+
+func TestParseResult(t *testing.T) {
+	proxies := ParseResult("PROXY proxy.local:8080; SOCKS5 socks.local:1080;DIRECT")
+	want := []Proxy{
+		{Type: "PROXY", Host: "proxy.local:8080"},
+		{Type: "SOCKS5", Host: "socks.local:1080"},
+		{Type: "DIRECT"},
+	}
+	if len(proxies) != len(want) {
+		t.Fatalf("se obtuvieron %d alternativas, se esperaban %d", len(proxies), len(want))
+	}
+	for i := range want {
+		if proxies[i] != want[i] {
+			t.Errorf("alternativa %d: %+v, se esperaba %+v", i, proxies[i], want[i])
+		}
+	}
+
+	u, err := proxies[0].URL()
+	if err != nil || u.String() != "http://proxy.local:8080" {
+		t.Errorf("URL del proxy: %v %v", u, err)
+	}
+	if u, err := proxies[2].URL(); err != nil || u != nil {
+		t.Errorf("DIRECT no debería tener URL: %v %v", u, err)
+	}
+	if _, err := (Proxy{Type: "FTP", Host: "ftp:21"}).URL(); err == nil {
+		t.Error("se esperaba un error para un tipo de proxy no soportado")
+	}
+}
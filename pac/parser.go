@@ -0,0 +1,614 @@
+package pac
+
+import (
+	"polynode/i18n"
+)
+
+// Nodos del árbol sintáctico del subconjunto de JavaScript usado por los archivos PAC
+
+type stmt interface{}
+type expr interface{}
+
+type funcDecl struct {
+	name   string
+	params []string
+	body   []stmt
+}
+
+type varDecl struct {
+	names []string
+	inits []expr
+}
+
+type ifStmt struct {
+	cond expr
+	then stmt
+	els  stmt
+}
+
+type forStmt struct {
+	init   stmt
+	cond   expr
+	update expr
+	body   stmt
+}
+
+type whileStmt struct {
+	cond expr
+	body stmt
+}
+
+type returnStmt struct{ value expr }
+type blockStmt struct{ stmts []stmt }
+type exprStmt struct{ x expr }
+type breakStmt struct{}
+type continueStmt struct{}
+
+type literal struct{ value Value }
+type ident struct{ name string }
+type arrayLit struct{ elems []expr }
+type funcExpr struct {
+	params []string
+	body   []stmt
+}
+type unaryExpr struct {
+	op string
+	x  expr
+}
+type binaryExpr struct {
+	op   string
+	l, r expr
+}
+type conditionalExpr struct{ cond, a, b expr }
+type assignExpr struct {
+	op     string
+	target expr
+	value  expr
+}
+type updateExpr struct {
+	op     string
+	prefix bool
+	target expr
+}
+type callExpr struct {
+	callee expr
+	args   []expr
+}
+type memberExpr struct {
+	obj  expr
+	name string
+}
+type indexExpr struct{ obj, index expr }
+
+// Sentencias de JavaScript que el intérprete no soporta; se rechazan al interpretar el archivo
+var unsupportedStatements = map[string]string{
+	"do":     "do…while",
+	"switch": "switch",
+	"try":    "try…catch",
+	"throw":  "throw",
+	"with":   "with",
+	"class":  "class",
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func parse(src string) ([]stmt, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	var program []stmt
+	for p.peek().kind != tokenEOF {
+		s, err := p.statement()
+		if err != nil {
+			return nil, err
+		}
+		program = append(program, s)
+	}
+	return program, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) is(text string) bool {
+	t := p.peek()
+	return (t.kind == tokenPunct || t.kind == tokenIdent) && t.text == text
+}
+
+func (p *parser) accept(text string) bool {
+	if p.is(text) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(text string) error {
+	if !p.accept(text) {
+		t := p.peek()
+		return i18n.Errorf("pac.expected", t.line, text, t.text)
+	}
+	return nil
+}
+
+func (p *parser) identifier() (string, error) {
+	t := p.next()
+	if t.kind != tokenIdent {
+		return "", i18n.Errorf("pac.expected_identifier", t.line, t.text)
+	}
+	return t.text, nil
+}
+
+func (p *parser) statement() (stmt, error) {
+	switch {
+	case p.accept(";"):
+		return blockStmt{}, nil
+
+	case p.is("{"):
+		return p.block()
+
+	case p.accept("function"):
+		name, err := p.identifier()
+		if err != nil {
+			return nil, err
+		}
+		params, body, err := p.functionRest()
+		if err != nil {
+			return nil, err
+		}
+		return funcDecl{name: name, params: params, body: body}, nil
+
+	case p.is("var") || p.is("let") || p.is("const"):
+		s, err := p.varDeclaration()
+		if err != nil {
+			return nil, err
+		}
+		p.accept(";")
+		return s, nil
+
+	case p.accept("if"):
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		cond, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		then, err := p.statement()
+		if err != nil {
+			return nil, err
+		}
+		var els stmt
+		if p.accept("else") {
+			if els, err = p.statement(); err != nil {
+				return nil, err
+			}
+		}
+		return ifStmt{cond: cond, then: then, els: els}, nil
+
+	case p.accept("for"):
+		return p.forStatement()
+
+	case p.accept("while"):
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		cond, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		body, err := p.statement()
+		if err != nil {
+			return nil, err
+		}
+		return whileStmt{cond: cond, body: body}, nil
+
+	case p.accept("return"):
+		var value expr
+		if !p.is(";") && !p.is("}") {
+			var err error
+			if value, err = p.expression(); err != nil {
+				return nil, err
+			}
+		}
+		p.accept(";")
+		return returnStmt{value: value}, nil
+
+	case p.accept("break"):
+		p.accept(";")
+		return breakStmt{}, nil
+
+	case p.accept("continue"):
+		p.accept(";")
+		return continueStmt{}, nil
+	}
+
+	if t := p.peek(); t.kind == tokenIdent {
+		if name, ok := unsupportedStatements[t.text]; ok {
+			return nil, i18n.Errorf("pac.unsupported_keyword", t.line, name)
+		}
+	}
+
+	x, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	p.accept(";")
+	return exprStmt{x: x}, nil
+}
+
+func (p *parser) block() (stmt, error) {
+	stmts, err := p.blockBody()
+	if err != nil {
+		return nil, err
+	}
+	return blockStmt{stmts: stmts}, nil
+}
+
+func (p *parser) blockBody() ([]stmt, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var stmts []stmt
+	for !p.accept("}") {
+		if p.peek().kind == tokenEOF {
+			return nil, i18n.Errorf("pac.expected_brace", p.peek().line)
+		}
+		s, err := p.statement()
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, s)
+	}
+	return stmts, nil
+}
+
+func (p *parser) functionRest() ([]string, []stmt, error) {
+	if err := p.expect("("); err != nil {
+		return nil, nil, err
+	}
+	var params []string
+	for !p.accept(")") {
+		name, err := p.identifier()
+		if err != nil {
+			return nil, nil, err
+		}
+		params = append(params, name)
+		if !p.is(")") {
+			if err := p.expect(","); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	body, err := p.blockBody()
+	if err != nil {
+		return nil, nil, err
+	}
+	return params, body, nil
+}
+
+func (p *parser) varDeclaration() (stmt, error) {
+	p.next()
+	decl := varDecl{}
+	for {
+		name, err := p.identifier()
+		if err != nil {
+			return nil, err
+		}
+		var init expr
+		if p.accept("=") {
+			if init, err = p.assignment(); err != nil {
+				return nil, err
+			}
+		}
+		decl.names = append(decl.names, name)
+		decl.inits = append(decl.inits, init)
+		if !p.accept(",") {
+			return decl, nil
+		}
+	}
+}
+
+func (p *parser) forStatement() (stmt, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	s := forStmt{}
+	var err error
+	if p.is("var") || p.is("let") || p.is("const") {
+		if s.init, err = p.varDeclaration(); err != nil {
+			return nil, err
+		}
+	} else if !p.is(";") {
+		x, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		s.init = exprStmt{x: x}
+	}
+	if err := p.expect(";"); err != nil {
+		return nil, err
+	}
+	if !p.is(";") {
+		if s.cond, err = p.expression(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expect(";"); err != nil {
+		return nil, err
+	}
+	if !p.is(")") {
+		if s.update, err = p.expression(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	if s.body, err = p.statement(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (p *parser) expression() (expr, error) {
+	x, err := p.assignment()
+	if err != nil {
+		return nil, err
+	}
+	// El operador coma evalúa ambas expresiones y devuelve la última
+	for p.accept(",") {
+		right, err := p.assignment()
+		if err != nil {
+			return nil, err
+		}
+		x = binaryExpr{op: ",", l: x, r: right}
+	}
+	return x, nil
+}
+
+func (p *parser) assignment() (expr, error) {
+	target, err := p.conditional()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range []string{"=", "+=", "-="} {
+		if p.accept(op) {
+			switch target.(type) {
+			case ident, memberExpr, indexExpr:
+			default:
+				return nil, i18n.Errorf("pac.invalid_assignment", p.peek().line)
+			}
+			value, err := p.assignment()
+			if err != nil {
+				return nil, err
+			}
+			return assignExpr{op: op, target: target, value: value}, nil
+		}
+	}
+	return target, nil
+}
+
+func (p *parser) conditional() (expr, error) {
+	cond, err := p.binary(0)
+	if err != nil {
+		return nil, err
+	}
+	if !p.accept("?") {
+		return cond, nil
+	}
+	a, err := p.assignment()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	b, err := p.assignment()
+	if err != nil {
+		return nil, err
+	}
+	return conditionalExpr{cond: cond, a: a, b: b}, nil
+}
+
+// Operadores binarios agrupados por precedencia, de menor a mayor
+var binaryLevels = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!=", "===", "!=="},
+	{"<", ">", "<=", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *parser) binary(level int) (expr, error) {
+	if level == len(binaryLevels) {
+		return p.unary()
+	}
+
+	left, err := p.binary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		matched := ""
+		for _, op := range binaryLevels[level] {
+			if p.peek().kind == tokenPunct && p.peek().text == op {
+				matched = op
+				break
+			}
+		}
+		if matched == "" {
+			return left, nil
+		}
+		p.next()
+		right, err := p.binary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = binaryExpr{op: matched, l: left, r: right}
+	}
+}
+
+func (p *parser) unary() (expr, error) {
+	for _, op := range []string{"!", "-", "+", "typeof"} {
+		if p.accept(op) {
+			x, err := p.unary()
+			if err != nil {
+				return nil, err
+			}
+			return unaryExpr{op: op, x: x}, nil
+		}
+	}
+	for _, op := range []string{"++", "--"} {
+		if p.accept(op) {
+			x, err := p.unary()
+			if err != nil {
+				return nil, err
+			}
+			return updateExpr{op: op, prefix: true, target: x}, nil
+		}
+	}
+
+	x, err := p.postfix()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range []string{"++", "--"} {
+		if p.accept(op) {
+			return updateExpr{op: op, target: x}, nil
+		}
+	}
+	return x, nil
+}
+
+func (p *parser) postfix() (expr, error) {
+	x, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		switch {
+		case p.accept("."):
+			name, err := p.identifier()
+			if err != nil {
+				return nil, err
+			}
+			x = memberExpr{obj: x, name: name}
+		case p.accept("["):
+			index, err := p.expression()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			x = indexExpr{obj: x, index: index}
+		case p.accept("("):
+			var args []expr
+			for !p.accept(")") {
+				arg, err := p.assignment()
+				if err != nil {
+					return nil, err
+				}
+				args = append(args, arg)
+				if !p.is(")") {
+					if err := p.expect(","); err != nil {
+						return nil, err
+					}
+				}
+			}
+			x = callExpr{callee: x, args: args}
+		default:
+			return x, nil
+		}
+	}
+}
+
+func (p *parser) primary() (expr, error) {
+	t := p.next()
+
+	switch t.kind {
+	case tokenNumber:
+		return literal{value: t.num}, nil
+	case tokenString:
+		return literal{value: t.text}, nil
+	case tokenIdent:
+		switch t.text {
+		case "true":
+			return literal{value: true}, nil
+		case "false":
+			return literal{value: false}, nil
+		case "null", "undefined":
+			return literal{value: nil}, nil
+		case "function":
+			params, body, err := p.functionRest()
+			if err != nil {
+				return nil, err
+			}
+			return funcExpr{params: params, body: body}, nil
+		case "new":
+			return nil, i18n.Errorf("pac.unsupported_operator", t.line, t.text)
+		}
+		return ident{name: t.text}, nil
+	case tokenPunct:
+		switch t.text {
+		case "(":
+			x, err := p.expression()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return x, nil
+		case "[":
+			arr := arrayLit{}
+			for !p.accept("]") {
+				elem, err := p.assignment()
+				if err != nil {
+					return nil, err
+				}
+				arr.elems = append(arr.elems, elem)
+				if !p.is("]") {
+					if err := p.expect(","); err != nil {
+						return nil, err
+					}
+				}
+			}
+			return arr, nil
+		case "{":
+			return nil, i18n.Errorf("pac.unsupported_object", t.line)
+		}
+	}
+
+	if t.kind == tokenEOF {
+		return nil, i18n.Errorf("pac.unexpected_eof", t.line)
+	}
+	return nil, i18n.Errorf("pac.unexpected_token", t.line, t.text)
+}
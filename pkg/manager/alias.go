@@ -0,0 +1,243 @@
+package manager
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"polynode/i18n"
+	"polynode/shared"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultAlias es el alias de la versión que se selecciona con "poly use" sin parámetros
+const DefaultAlias = "default"
+
+const aliasesFileVersion = 1
+
+// aliasNamePattern son los nombres de alias válidos; no pueden empezar con un número para no confundirse con versiones
+var aliasNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9._-]*$`)
+
+// reservedAliases son nombres que ya tienen un significado como versión
+var reservedAliases = map[string]bool{"lts": true, "current": true}
+
+// Alias es un nombre asignado a una versión de Node
+type Alias struct {
+	Name string `json:"-"`
+	// Target es la versión tal como se indicó ("18.19.0", "20" o "lts")
+	Target string `json:"target"`
+	// Version es la versión completa a la que se resolvió Target
+	Version string `json:"version"`
+}
+
+// AliasChange es el resultado de volver a resolver un alias
+type AliasChange struct {
+	Alias
+	// Previous es la versión a la que apuntaba el alias antes de actualizarlo
+	Previous string
+}
+
+// aliasesFile es el contenido de aliases.json
+type aliasesFile struct {
+	Version int              `json:"version"`
+	Aliases map[string]Alias `json:"aliases"`
+}
+
+// Channel indica si el alias apunta a una línea de versiones ("lts", "20") en lugar de a una versión concreta
+func (a Alias) Channel() bool {
+	return strings.Count(shared.NormalizeVersion(a.Target), ".") < 2
+}
+
+/*
+follows indica si version pertenece a la línea de versiones del alias: "20.11.2" pertenece a
+"20" y a "20.11", pero no a "20.10". Los canales con nombre ("lts") siguen a cualquier versión,
+y un alias de una versión concreta sólo sigue a esa versión.
+*/
+func (a Alias) follows(version string) bool {
+	target := shared.NormalizeVersion(a.Target)
+	if !a.Channel() {
+		return target == version
+	}
+	if _, err := strconv.Atoi(strings.Split(target, ".")[0]); err != nil {
+		return true
+	}
+	return strings.HasPrefix(version, target+".")
+}
+
+// AliasesPath devuelve la ruta del archivo de alias del espacio de trabajo
+func AliasesPath() string {
+	return filepath.Join(shared.GetInstallPath(), "aliases.json")
+}
+
+// ValidateAliasName comprueba que un nombre se pueda usar como alias
+func ValidateAliasName(name string) error {
+	if !aliasNamePattern.MatchString(name) || reservedAliases[name] {
+		return i18n.Errorf("alias.invalid_name", name)
+	}
+	return nil
+}
+
+// Aliases devuelve los alias definidos, ordenados por nombre
+func Aliases() ([]Alias, error) {
+	file, err := readAliases()
+	if err != nil {
+		return nil, err
+	}
+
+	var aliases []Alias
+	for name, alias := range file.Aliases {
+		alias.Name = name
+		aliases = append(aliases, alias)
+	}
+	sort.Slice(aliases, func(i, j int) bool {
+		return aliases[i].Name < aliases[j].Name
+	})
+	return aliases, nil
+}
+
+// LookupAlias busca un alias por su nombre
+func LookupAlias(name string) (Alias, bool) {
+	file, err := readAliases()
+	if err != nil {
+		return Alias{}, false
+	}
+	alias, ok := file.Aliases[name]
+	alias.Name = name
+	return alias, ok
+}
+
+/*
+SetAlias crea o reemplaza un alias. La versión se resuelve como en Install, por lo que "lts" o
+"20" se convierten en la versión más nueva según el índice del mirror; RefreshAliases las
+vuelve a resolver más adelante.
+*/
+func (m *Manager) SetAlias(name, target string) (Alias, error) {
+	if err := ValidateAliasName(name); err != nil {
+		return Alias{}, err
+	}
+
+	version, err := m.Resolve(target)
+	if err != nil {
+		return Alias{}, err
+	}
+
+	file, err := readAliases()
+	if err != nil {
+		return Alias{}, err
+	}
+	alias := Alias{Name: name, Target: target, Version: version}
+	file.Aliases[name] = alias
+	return alias, writeAliases(file)
+}
+
+// RemoveAlias elimina un alias
+func RemoveAlias(name string) error {
+	file, err := readAliases()
+	if err != nil {
+		return err
+	}
+	if _, ok := file.Aliases[name]; !ok {
+		return categorize(ErrNotFound, i18n.Errorf("alias.not_found", name))
+	}
+	delete(file.Aliases, name)
+	return writeAliases(file)
+}
+
+// RefreshAliases vuelve a resolver los alias que apuntan a una línea de versiones y devuelve los que cambiaron
+func (m *Manager) RefreshAliases() ([]AliasChange, error) {
+	aliases, err := Aliases()
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []AliasChange
+	for _, alias := range aliases {
+		if !alias.Channel() {
+			continue
+		}
+		refreshed, err := m.SetAlias(alias.Name, alias.Target)
+		if err != nil {
+			return changes, err
+		}
+		if refreshed.Version != alias.Version {
+			changes = append(changes, AliasChange{Alias: refreshed, Previous: alias.Version})
+		}
+	}
+	return changes, nil
+}
+
+/*
+RetargetAliases hace que los alias que apuntan a la versión from apunten a la versión to y
+devuelve los que cambiaron. Los alias de una versión concreta pasan a tener to como destino;
+los de una línea de versiones conservan la línea y sólo cambian si to pertenece a ella (un
+alias "20.11" no pasa a la 20.12.0).
+*/
+func RetargetAliases(from, to string) ([]AliasChange, error) {
+	aliases, err := Aliases()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := readAliases()
+	if err != nil {
+		return nil, err
+	}
+	var changes []AliasChange
+	for _, alias := range aliases {
+		if alias.Version != from {
+			continue
+		}
+		if alias.Channel() && !alias.follows(to) {
+			continue
+		}
+		retargeted := Alias{Name: alias.Name, Target: alias.Target, Version: to}
+		if !alias.Channel() {
+			retargeted.Target = to
+		}
+		file.Aliases[alias.Name] = retargeted
+		changes = append(changes, AliasChange{Alias: retargeted, Previous: from})
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	return changes, writeAliases(file)
+}
+
+// expandAlias devuelve la versión del alias indicado, o spec sin cambios si no es un alias
+func expandAlias(spec string) string {
+	if alias, ok := LookupAlias(spec); ok {
+		return alias.Version
+	}
+	return spec
+}
+
+func readAliases() (*aliasesFile, error) {
+	file := &aliasesFile{Version: aliasesFileVersion, Aliases: map[string]Alias{}}
+	data, err := os.ReadFile(AliasesPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return file, nil
+		}
+		return nil, i18n.Errorf("alias.read_error", AliasesPath(), err)
+	}
+	if err := json.Unmarshal(data, file); err != nil {
+		return nil, i18n.Errorf("alias.decode_error", AliasesPath(), err)
+	}
+	if file.Aliases == nil {
+		file.Aliases = map[string]Alias{}
+	}
+	return file, nil
+}
+
+func writeAliases(file *aliasesFile) error {
+	data, err := json.MarshalIndent(file, "", "    ")
+	if err != nil {
+		return i18n.Errorf("alias.write_error", err)
+	}
+	if err := os.WriteFile(AliasesPath(), data, 0644); err != nil {
+		return i18n.Errorf("alias.write_error", err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package manager
+
+import (
+	"polynode/shared"
+	"testing"
+)
+
+func TestRetargetAliases(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want map[string]Alias
+	}{
+		{
+			name: "nueva versión menor",
+			from: "20.11.1",
+			to:   "20.12.2",
+			want: map[string]Alias{
+				"pinned": {Target: "20.12.2", Version: "20.12.2"},
+				"twenty": {Target: "20", Version: "20.12.2"},
+				"minor":  {Target: "20.11", Version: "20.11.1"},
+				"stable": {Target: "lts", Version: "20.12.2"},
+				"old":    {Target: "18.19.0", Version: "18.19.0"},
+			},
+		},
+		{
+			name: "nueva revisión",
+			from: "20.11.1",
+			to:   "20.11.2",
+			want: map[string]Alias{
+				"pinned": {Target: "20.11.2", Version: "20.11.2"},
+				"twenty": {Target: "20", Version: "20.11.2"},
+				"minor":  {Target: "20.11", Version: "20.11.2"},
+				"stable": {Target: "lts", Version: "20.11.2"},
+				"old":    {Target: "18.19.0", Version: "18.19.0"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			shared.SetInstallPath(t.TempDir())
+			err := writeAliases(&aliasesFile{Version: aliasesFileVersion, Aliases: map[string]Alias{
+				"pinned": {Target: "20.11.1", Version: "20.11.1"},
+				"twenty": {Target: "20", Version: "20.11.1"},
+				"minor":  {Target: "20.11", Version: "20.11.1"},
+				"stable": {Target: "lts", Version: "20.11.1"},
+				"old":    {Target: "18.19.0", Version: "18.19.0"},
+			}})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := RetargetAliases(test.from, test.to); err != nil {
+				t.Fatal(err)
+			}
+			for name, want := range test.want {
+				got, ok := LookupAlias(name)
+				if !ok {
+					t.Fatalf("no se encontró el alias %s", name)
+				}
+				if got.Target != want.Target || got.Version != want.Version {
+					t.Errorf("alias %s: %s (%s), se esperaba %s (%s)", name, got.Target, got.Version, want.Target, want.Version)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package manager
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"polynode/i18n"
+	"strings"
+)
+
+// SafeJoin arma la ruta de un archivo extraído, rechazando rutas que salgan del directorio destino
+func SafeJoin(dest, name string) (string, error) {
+	path := filepath.Join(dest, name)
+	if path != filepath.Clean(dest) && !strings.HasPrefix(path, filepath.Clean(dest)+string(os.PathSeparator)) {
+		return "", i18n.Errorf("archive.invalid_path", name)
+	}
+	return path, nil
+}
+
+/*
+ExtractArchive extrae un archivo .zip, .tar.gz/.tgz o .tar.xz en el directorio destino.
+Los archivos .tar.xz se extraen con el comando tar del sistema, ya que Go no incluye
+un descompresor xz.
+*/
+func ExtractArchive(src, dest string) error {
+	name := strings.ToLower(src)
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		return unzip(src, dest)
+	case strings.HasSuffix(name, ".tar.gz") || strings.HasSuffix(name, ".tgz"):
+		return untarGz(src, dest)
+	case strings.HasSuffix(name, ".tar.xz"):
+		return untarWithSystemTar(src, dest)
+	}
+	return i18n.Errorf("archive.unsupported_format", filepath.Base(src))
+}
+
+func untarGz(src, dest string) error {
+	file, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	return untar(gz, dest)
+}
+
+func untar(r io.Reader, dest string) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		path, err := SafeJoin(dest, header.Name)
+		if err != nil {
+			return err
+		}
+		if err := CheckNoSymlinks(dest, path, header.Name); err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, os.FileMode(header.Mode)|0700); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+				return err
+			}
+			// Un enlace previo con el mismo nombre se reemplaza, para no escribir a través de él
+			if info, err := os.Lstat(path); err == nil && info.Mode()&os.ModeSymlink != 0 {
+				if err := os.Remove(path); err != nil {
+					return err
+				}
+			}
+			if err := WriteFile(path, tr, os.FileMode(header.Mode)); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := CheckLinkTarget(dest, path, header.Name, header.Linkname); err != nil {
+				return err
+			}
+			if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+				return err
+			}
+			os.Remove(path)
+			if err := os.Symlink(header.Linkname, path); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// CheckLinkTarget rechaza los enlaces simbólicos absolutos o cuyo destino queda fuera de dest
+func CheckLinkTarget(dest, path, name, linkname string) error {
+	// En Windows, "/tmp" no es una ruta absoluta para filepath.IsAbs pero sí parte de la raíz
+	if filepath.IsAbs(linkname) || strings.HasPrefix(filepath.ToSlash(linkname), "/") {
+		return i18n.Errorf("archive.invalid_link", name, linkname)
+	}
+	target := filepath.Join(filepath.Dir(path), linkname)
+	root := filepath.Clean(dest)
+	if target != root && !strings.HasPrefix(target, root+string(os.PathSeparator)) {
+		return i18n.Errorf("archive.invalid_link", name, linkname)
+	}
+	return nil
+}
+
+/*
+CheckNoSymlinks comprueba que ninguno de los directorios entre dest y path sea un enlace
+simbólico. Aunque el destino de cada enlace se valida, una cadena de enlaces puede salir del
+directorio destino (por ejemplo, "a/b -> .." y "c -> a/b/.."), así que nunca se escribe a
+través de un enlace creado por el mismo archivo.
+*/
+func CheckNoSymlinks(dest, path, name string) error {
+	rel, err := filepath.Rel(dest, filepath.Dir(path))
+	if err != nil || rel == "." {
+		return err
+	}
+	current := dest
+	for _, part := range strings.Split(rel, string(os.PathSeparator)) {
+		current = filepath.Join(current, part)
+		info, err := os.Lstat(current)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return i18n.Errorf("archive.symlink_in_path", name)
+		}
+	}
+	return nil
+}
+
+// WriteFile crea un archivo con el contenido de r y los permisos indicados
+func WriteFile(path string, r io.Reader, mode os.FileMode) error {
+	outFile, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode|0600)
+	if err != nil {
+		return err
+	}
+	defer outFile.Close()
+
+	_, err = io.Copy(outFile, r)
+	return err
+}
+
+func untarWithSystemTar(src, dest string) error {
+	if err := os.MkdirAll(dest, os.ModePerm); err != nil {
+		return err
+	}
+
+	cmd := exec.Command("tar", "-xJf", src, "-C", dest)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return i18n.Errorf("archive.tar_error", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func unzip(src, dest string) error {
+	r, err := zip.OpenReader(src)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		path, err := SafeJoin(dest, f.Name)
+		if err != nil {
+			return err
+		}
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(path, os.ModePerm); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			return err
+		}
+		if err := extractZipFile(f, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extractZipFile extrae un archivo del zip, cerrando los descriptores antes de pasar al siguiente
+func extractZipFile(f *zip.File, path string) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	outFile, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer outFile.Close()
+
+	_, err = io.Copy(outFile, rc)
+	return err
+}
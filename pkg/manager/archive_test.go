@@ -0,0 +1,137 @@
+package manager
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// tarEntry es un archivo, directorio o enlace simbólico de un tar armado para las pruebas
+type tarEntry struct {
+	name     string
+	linkname string
+	body     string
+	dir      bool
+}
+
+func buildTar(t *testing.T, entries []tarEntry) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, entry := range entries {
+		header := &tar.Header{Name: entry.name, Mode: 0755}
+		switch {
+		case entry.dir:
+			header.Typeflag = tar.TypeDir
+		case entry.linkname != "":
+			header.Typeflag = tar.TypeSymlink
+			header.Linkname = entry.linkname
+		default:
+			header.Typeflag = tar.TypeReg
+			header.Size = int64(len(entry.body))
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if header.Typeflag == tar.TypeReg {
+			if _, err := tw.Write([]byte(entry.body)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+func TestUntarRejectsEscapingSymlinks(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []tarEntry
+	}{
+		{
+			name: "enlace absoluto",
+			entries: []tarEntry{
+				{name: "esc", linkname: "OUTSIDE"},
+				{name: "esc/escaped", body: "x"},
+			},
+		},
+		{
+			name: "enlace relativo fuera del destino",
+			entries: []tarEntry{
+				{name: "node/esc", linkname: "../../outside"},
+				{name: "node/esc/escaped", body: "x"},
+			},
+		},
+		{
+			name: "cadena de enlaces",
+			entries: []tarEntry{
+				{name: "a/b", linkname: ".."},
+				{name: "c", linkname: "a/b/.."},
+				{name: "c/outside/escaped", body: "x"},
+			},
+		},
+		{
+			name: "archivo a través de un enlace interno",
+			entries: []tarEntry{
+				{name: "lib", dir: true},
+				{name: "link", linkname: "lib"},
+				{name: "link/escaped", body: "x"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := t.TempDir()
+			dest := filepath.Join(root, "dest")
+			outside := filepath.Join(root, "outside")
+			if err := os.MkdirAll(outside, 0755); err != nil {
+				t.Fatal(err)
+			}
+			for i := range test.entries {
+				if test.entries[i].linkname == "OUTSIDE" {
+					test.entries[i].linkname = outside
+				}
+			}
+
+			if err := untar(buildTar(t, test.entries), dest); err == nil {
+				t.Fatal("se esperaba un error al extraer el archivo")
+			}
+			if _, err := os.Stat(filepath.Join(outside, "escaped")); err == nil {
+				t.Fatal("se escribió un archivo fuera del directorio destino")
+			}
+		})
+	}
+}
+
+func TestUntarKeepsInternalSymlinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("crear enlaces simbólicos en Windows requiere permisos de administrador")
+	}
+	dest := t.TempDir()
+	archive := buildTar(t, []tarEntry{
+		{name: "node/lib/node_modules/npm/bin/npm-cli.js", body: "npm"},
+		{name: "node/bin/npm", linkname: "../lib/node_modules/npm/bin/npm-cli.js"},
+	})
+	if err := untar(archive, dest); err != nil {
+		t.Fatal(err)
+	}
+
+	link := filepath.Join(dest, "node", "bin", "npm")
+	target, err := os.Readlink(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if target != "../lib/node_modules/npm/bin/npm-cli.js" {
+		t.Fatalf("destino del enlace inesperado: %s", target)
+	}
+	data, err := os.ReadFile(link)
+	if err != nil || string(data) != "npm" {
+		t.Fatalf("no se pudo leer el archivo a través del enlace: %v", err)
+	}
+}
@@ -0,0 +1,225 @@
+package manager
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"polynode/i18n"
+	"polynode/shared"
+	"sort"
+	"sync"
+	"time"
+)
+
+/*
+Los archivos descargados se guardan en la caché del espacio de trabajo con la estructura
+cache/archives/<sha256>/<nombre del archivo>. La fecha de modificación de cada archivo se
+actualiza cada vez que se reutiliza, y se usa para la limpieza y para desalojar los menos
+usados cuando la caché supera cache_max_size.
+*/
+
+// CachedArchive es un archivo guardado en la caché de descargas
+type CachedArchive struct {
+	Path     string
+	Name     string
+	SHA256   string
+	Size     int64
+	LastUsed time.Time
+}
+
+// cacheMutex evita que varias descargas en paralelo modifiquen la caché a la vez
+var cacheMutex sync.Mutex
+
+// ListCachedArchives devuelve los archivos de la caché, del más recientemente usado al menos usado
+func ListCachedArchives() ([]CachedArchive, error) {
+	root := shared.GetArchiveCachePath()
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, i18n.Errorf("cache.read_error", err)
+	}
+
+	var archives []CachedArchive
+	for _, entry := range entries {
+		// Sólo los directorios con nombre de hash SHA-256 forman parte de la caché
+		if !entry.IsDir() || !isSHA256(entry.Name()) {
+			continue
+		}
+		files, err := os.ReadDir(filepath.Join(root, entry.Name()))
+		if err != nil {
+			continue
+		}
+		for _, file := range files {
+			info, err := file.Info()
+			if err != nil || !info.Mode().IsRegular() {
+				continue
+			}
+			archives = append(archives, CachedArchive{
+				Path:     filepath.Join(root, entry.Name(), file.Name()),
+				Name:     file.Name(),
+				SHA256:   entry.Name(),
+				Size:     info.Size(),
+				LastUsed: info.ModTime(),
+			})
+		}
+	}
+
+	sort.Slice(archives, func(i, j int) bool {
+		return archives[i].LastUsed.After(archives[j].LastUsed)
+	})
+	return archives, nil
+}
+
+// isSHA256 indica si name es un hash SHA-256 en hexadecimal
+func isSHA256(name string) bool {
+	decoded, err := hex.DecodeString(name)
+	return err == nil && len(decoded) == sha256.Size
+}
+
+/*
+FindCachedArchive busca un archivo en la caché por nombre y hash. Si el hash esperado no se
+conoce (por ejemplo, sin acceso al mirror), se usa el archivo más reciente con ese nombre.
+*/
+func FindCachedArchive(name, sha string) (string, bool) {
+	cacheMutex.Lock()
+	defer cacheMutex.Unlock()
+
+	if sha != "" {
+		path := filepath.Join(shared.GetArchiveCachePath(), sha, name)
+		if _, err := os.Stat(path); err == nil {
+			touchCachedArchive(path)
+			return path, true
+		}
+		return "", false
+	}
+
+	archives, err := ListCachedArchives()
+	if err != nil {
+		return "", false
+	}
+	for _, archive := range archives {
+		if archive.Name == name {
+			touchCachedArchive(archive.Path)
+			return archive.Path, true
+		}
+	}
+	return "", false
+}
+
+func touchCachedArchive(path string) {
+	now := time.Now()
+	os.Chtimes(path, now, now)
+}
+
+/*
+StoreInCache guarda en la caché el contenido leído de r, calculando su SHA-256.
+Si expectedSHA no está vacío y no coincide, el archivo se descarta.
+*/
+func StoreInCache(r io.Reader, name, expectedSHA string) (string, error) {
+	root := shared.GetArchiveCachePath()
+	if err := os.MkdirAll(root, os.ModePerm); err != nil {
+		return "", i18n.Errorf("cache.mkdir_error", err)
+	}
+
+	tmpFile, err := os.CreateTemp(root, name+".*.tmp")
+	if err != nil {
+		return "", i18n.Errorf("cache.create_file_error", name, err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tmpFile, hash), r)
+	tmpFile.Close()
+	if err != nil {
+		return "", i18n.Errorf("cache.save_file_error", name, err)
+	}
+
+	sha := hex.EncodeToString(hash.Sum(nil))
+	if expectedSHA != "" && sha != expectedSHA {
+		return "", categorize(ErrIntegrity, i18n.Errorf("cache.sha_mismatch", name, ChecksumsFileName, expectedSHA, sha))
+	}
+
+	cacheMutex.Lock()
+	defer cacheMutex.Unlock()
+
+	path := filepath.Join(root, sha, name)
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return "", i18n.Errorf("cache.mkdir_error", err)
+	}
+	if err := os.Rename(tmpFile.Name(), path); err != nil {
+		return "", i18n.Errorf("cache.move_error", name, err)
+	}
+
+	if err := enforceCacheLimit(path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// enforceCacheLimit desaloja los archivos menos usados hasta que la caché no supere cache_max_size
+func enforceCacheLimit(keep string) error {
+	limit, err := shared.ParseSize(shared.GetConfig("cache_max_size"))
+	if err != nil || limit <= 0 {
+		return nil
+	}
+
+	archives, err := ListCachedArchives()
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	for _, archive := range archives {
+		total += archive.Size
+	}
+
+	// Recorrer desde el menos usado
+	for i := len(archives) - 1; i >= 0 && total > limit; i-- {
+		if archives[i].Path == keep {
+			continue
+		}
+		if err := removeCachedArchive(archives[i]); err != nil {
+			return err
+		}
+		total -= archives[i].Size
+	}
+	return nil
+}
+
+// CleanCache elimina los archivos no usados en el período indicado, o todos si olderThan es cero
+func CleanCache(olderThan time.Duration) (int, int64, error) {
+	cacheMutex.Lock()
+	defer cacheMutex.Unlock()
+
+	archives, err := ListCachedArchives()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	removed := 0
+	var freed int64
+	for _, archive := range archives {
+		if olderThan > 0 && time.Since(archive.LastUsed) < olderThan {
+			continue
+		}
+		if err := removeCachedArchive(archive); err != nil {
+			return removed, freed, err
+		}
+		removed++
+		freed += archive.Size
+	}
+	return removed, freed, nil
+}
+
+func removeCachedArchive(archive CachedArchive) error {
+	if err := os.Remove(archive.Path); err != nil {
+		return i18n.Errorf("cache.remove_error", archive.Name, err)
+	}
+	// Eliminar el directorio del hash si quedó vacío
+	os.Remove(filepath.Dir(archive.Path))
+	return nil
+}
@@ -0,0 +1,114 @@
+package manager
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"polynode/i18n"
+	"polynode/shared"
+	"strings"
+)
+
+// ChecksumsFileName es el nombre del archivo con los hashes de los archivos de cada versión
+const ChecksumsFileName = "SHASUMS256.txt"
+
+// ParseChecksums interpreta el contenido de un archivo SHASUMS256.txt (hash y nombre de archivo por línea)
+func ParseChecksums(r io.Reader) map[string]string {
+	checksums := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		checksums[strings.TrimPrefix(fields[1], "*")] = strings.ToLower(fields[0])
+	}
+	return checksums
+}
+
+/*
+Checksums obtiene el archivo SHASUMS256.txt de una versión. Como el contenido de una
+versión publicada no cambia, se guarda en la caché y se reutiliza, lo que además permite
+verificar las instalaciones sin conexión.
+*/
+func (m *Manager) Checksums(version string) (map[string]string, error) {
+	cacheFile := ChecksumsCachePath(version)
+	if file, err := os.Open(cacheFile); err == nil {
+		defer file.Close()
+		return ParseChecksums(file), nil
+	}
+
+	body, err := m.DownloadChecksums(version)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := SaveChecksums(version, body); err != nil {
+		return nil, err
+	}
+	return ParseChecksums(bytes.NewReader(body)), nil
+}
+
+// DownloadChecksums descarga el archivo SHASUMS256.txt de una versión, sin usar la caché
+func (m *Manager) DownloadChecksums(version string) ([]byte, error) {
+	client, err := m.Client()
+	if err != nil {
+		return nil, err
+	}
+
+	checksumsURL := shared.GetNodeArchiveURL(version, ChecksumsFileName)
+
+	resp, err := client.Get(checksumsURL)
+	if err != nil {
+		return nil, categorize(ErrNetwork, i18n.Errorf("checksums.get_error", ChecksumsFileName, err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, categorize(ErrNetwork, i18n.Errorf("checksums.get_status_error", ChecksumsFileName, resp.Status))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, i18n.Errorf("checksums.read_error", ChecksumsFileName, err)
+	}
+	return body, nil
+}
+
+// ChecksumsCachePath devuelve la ruta del SHASUMS256.txt de una versión guardado en la caché
+func ChecksumsCachePath(version string) string {
+	return filepath.Join(shared.GetCachePath(), "checksums", fmt.Sprintf("v%s-%s", version, ChecksumsFileName))
+}
+
+// SaveChecksums guarda en la caché el contenido de SHASUMS256.txt de una versión
+func SaveChecksums(version string, body []byte) error {
+	cacheFile := ChecksumsCachePath(version)
+	if err := os.MkdirAll(filepath.Dir(cacheFile), os.ModePerm); err != nil {
+		return i18n.Errorf("cache.mkdir_error", err)
+	}
+	if err := os.WriteFile(cacheFile, body, 0644); err != nil {
+		return i18n.Errorf("checksums.save_error", ChecksumsFileName, err)
+	}
+	return nil
+}
+
+// FileSHA256 calcula el hash SHA-256 de un archivo
+func FileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
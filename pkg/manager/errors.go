@@ -0,0 +1,36 @@
+package manager
+
+import "errors"
+
+/*
+Categorías de los errores devueltos por el Manager. Permiten distinguir el tipo de falla sin
+depender del texto del mensaje:
+
+	if errors.Is(err, manager.ErrNetwork) { ... }
+*/
+var (
+	// ErrNotFound indica que la versión pedida no existe en el mirror o no está instalada
+	ErrNotFound = errors.New("no encontrado")
+	// ErrNetwork indica que no se pudo obtener un archivo del mirror
+	ErrNetwork = errors.New("error de red")
+	// ErrIntegrity indica que un archivo no coincide con el SHA-256 esperado
+	ErrIntegrity = errors.New("error de integridad")
+)
+
+// categorizedError asocia una categoría a un error conservando su mensaje
+type categorizedError struct {
+	category error
+	err      error
+}
+
+func (e *categorizedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *categorizedError) Unwrap() []error {
+	return []error{e.category, e.err}
+}
+
+func categorize(category, err error) error {
+	return &categorizedError{category: category, err: err}
+}
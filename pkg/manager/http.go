@@ -0,0 +1,179 @@
+package manager
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"polynode/i18n"
+	"polynode/shared"
+	"strings"
+)
+
+/*
+NewHTTPClient crea el cliente HTTP de las descargas con la configuración de polynode:
+tiempos de espera (timeout), TLS (certificados, versión mínima, insecure) y proxy
+(proxy_pac, http_proxy, https_proxy, no_proxy o las variables de entorno estándar).
+*/
+func NewHTTPClient() (*http.Client, error) {
+	timeout := shared.GetConfigDuration("timeout")
+	transport := &http.Transport{
+		DialContext:           (&net.Dialer{Timeout: timeout}).DialContext,
+		TLSHandshakeTimeout:   timeout,
+		ResponseHeaderTimeout: timeout,
+	}
+
+	tlsConfig, err := buildTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	proxy, err := buildProxyFunc(tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	transport.Proxy = proxy
+
+	return &http.Client{Transport: transport}, nil
+}
+
+/*
+buildProxyFunc arma la función que elige el proxy de cada solicitud.
+Si se configuró proxy_pac, el proxy se obtiene del archivo PAC; si no hay proxies configurados
+en polynode se usan las variables de entorno estándar (HTTP_PROXY, HTTPS_PROXY y NO_PROXY).
+Las credenciales guardadas con "poly proxy --user" se agregan a la URL del proxy cuando ésta
+no las incluye.
+*/
+func buildProxyFunc(tlsConfig *tls.Config) (func(*http.Request) (*url.URL, error), error) {
+	credentials, err := shared.LoadCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	if pacLocation := shared.GetConfig("proxy_pac"); pacLocation != "" {
+		script, err := loadPACScript(pacLocation, tlsConfig)
+		if err != nil {
+			return nil, err
+		}
+		return pacProxyFunc(script, credentials), nil
+	}
+
+	httpProxy, err := parseProxyURL(shared.GetConfig("http_proxy"), credentials)
+	if err != nil {
+		return nil, err
+	}
+	httpsProxy, err := parseProxyURL(shared.GetConfig("https_proxy"), credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpProxy == nil && httpsProxy == nil {
+		return func(req *http.Request) (*url.URL, error) {
+			proxyURL, err := http.ProxyFromEnvironment(req)
+			if proxyURL != nil {
+				addProxyCredentials(proxyURL, credentials)
+			}
+			return proxyURL, err
+		}, nil
+	}
+
+	// Si solo se configuró http_proxy, se usa también para las solicitudes HTTPS
+	if httpsProxy == nil {
+		httpsProxy = httpProxy
+	}
+	noProxy := parseNoProxy(shared.GetConfig("no_proxy"))
+
+	return func(req *http.Request) (*url.URL, error) {
+		if bypassProxy(req.URL, noProxy) {
+			return nil, nil
+		}
+		if req.URL.Scheme == "https" {
+			return httpsProxy, nil
+		}
+		return httpProxy, nil
+	}, nil
+}
+
+func parseProxyURL(rawURL string, credentials shared.Credentials) (*url.URL, error) {
+	if rawURL == "" {
+		return nil, nil
+	}
+
+	proxyURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, i18n.Errorf("http.invalid_proxy_url", rawURL, err)
+	}
+
+	addProxyCredentials(proxyURL, credentials)
+	return proxyURL, nil
+}
+
+func addProxyCredentials(proxyURL *url.URL, credentials shared.Credentials) {
+	if proxyURL.User != nil || credentials.ProxyUser == "" {
+		return
+	}
+	if credentials.ProxyPassword == "" {
+		proxyURL.User = url.User(credentials.ProxyUser)
+	} else {
+		proxyURL.User = url.UserPassword(credentials.ProxyUser, credentials.ProxyPassword)
+	}
+}
+
+func parseNoProxy(value string) []string {
+	var entries []string
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+/*
+bypassProxy indica si el host de la URL coincide con alguna entrada de no_proxy.
+Se aceptan las mismas formas que la variable NO_PROXY: "*", un host exacto, un dominio
+(con o sin punto inicial, que incluye sus subdominios), una IP o una red en notación CIDR,
+opcionalmente seguidos de ":puerto".
+*/
+func bypassProxy(target *url.URL, noProxy []string) bool {
+	host := strings.ToLower(target.Hostname())
+	port := target.Port()
+	if port == "" {
+		port = "80"
+		if target.Scheme == "https" {
+			port = "443"
+		}
+	}
+	ip := net.ParseIP(host)
+
+	for _, entry := range noProxy {
+		if entry == "*" {
+			return true
+		}
+
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			if ip != nil && network.Contains(ip) {
+				return true
+			}
+			continue
+		}
+
+		entryHost, entryPort, err := net.SplitHostPort(entry)
+		if err != nil {
+			entryHost, entryPort = entry, ""
+		}
+		if entryPort != "" && entryPort != port {
+			continue
+		}
+
+		entryHost = strings.TrimPrefix(entryHost, "*")
+		domain := strings.TrimPrefix(entryHost, ".")
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+
+	return false
+}
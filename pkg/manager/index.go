@@ -0,0 +1,269 @@
+package manager
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"polynode/i18n"
+	"polynode/shared"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IndexEntry es una versión publicada en el index.json del mirror
+type IndexEntry struct {
+	Version string `json:"version"`
+	// Date es la fecha de publicación (2024-02-14)
+	Date string `json:"date"`
+	// Files son los archivos publicados para cada plataforma ("linux-x64", "win-x64-zip")
+	Files []string `json:"files"`
+	// Npm, V8, Uv, Zlib y OpenSSL son las versiones de los componentes incluidos
+	Npm     string `json:"npm"`
+	V8      string `json:"v8"`
+	Uv      string `json:"uv"`
+	Zlib    string `json:"zlib"`
+	OpenSSL string `json:"openssl"`
+	// Modules es la versión de la ABI de los módulos nativos (NODE_MODULE_VERSION)
+	Modules string      `json:"modules"`
+	Lts     interface{} `json:"lts"`
+	// Security indica que la versión corrige vulnerabilidades de seguridad
+	Security bool `json:"security"`
+}
+
+// LTSName devuelve el nombre de la línea LTS de la versión ("Iron"), o una cadena vacía si no es LTS
+func (e IndexEntry) LTSName() string {
+	/*
+	 * El campo lts puede ser un boolean con el valor false o un string con un código de versión lts
+	 */
+	switch lts := e.Lts.(type) {
+	case bool:
+		if lts {
+			return "true"
+		}
+	case string:
+		return lts
+	}
+	return ""
+}
+
+/*
+HasPlatform indica si la versión se publicó para una plataforma ("linux-x64", "win-x64") en el
+formato que se instala: los identificadores de Windows de index.json llevan el tipo de archivo ("win-x64-zip").
+*/
+func (e IndexEntry) HasPlatform(platform string) bool {
+	for _, file := range e.Files {
+		if file == platform || file == platform+"-zip" {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+SecurityFixes devuelve las versiones de seguridad publicadas después de version en su misma línea
+(la misma versión mayor), de la más antigua a la más nueva. Si hay alguna, version tiene
+vulnerabilidades conocidas que se corrigen instalando la más nueva de la línea.
+*/
+func SecurityFixes(index []IndexEntry, version string) []IndexEntry {
+	parsed, err := ParseVersion(shared.NormalizeVersion(version))
+	if err != nil {
+		return nil
+	}
+	line := ReleaseLineName(parsed)
+
+	var fixes []IndexEntry
+	for _, entry := range index {
+		if !entry.Security {
+			continue
+		}
+		candidate, err := ParseVersion(shared.NormalizeVersion(entry.Version))
+		if err != nil || ReleaseLineName(candidate) != line || CompareVersions(candidate, parsed) <= 0 {
+			continue
+		}
+		fixes = append(fixes, entry)
+	}
+	sort.Slice(fixes, func(i, j int) bool {
+		v1, _ := ParseVersion(shared.NormalizeVersion(fixes[i].Version))
+		v2, _ := ParseVersion(shared.NormalizeVersion(fixes[j].Version))
+		return CompareVersions(v1, v2) < 0
+	})
+	return fixes
+}
+
+/*
+Index obtiene el contenido de index.json del mirror configurado.
+La respuesta se guarda en el espacio de trabajo y se reutiliza mientras no supere cache_ttl.
+Si el mirror no está disponible, se usa el índice guardado aunque esté vencido.
+*/
+func (m *Manager) Index() ([]IndexEntry, error) {
+	cacheFile := IndexCachePath()
+
+	body, err := readCachedFile(cacheFile)
+	if err != nil {
+		body, err = m.DownloadIndex()
+		if err != nil {
+			stale, staleErr := os.ReadFile(cacheFile)
+			if staleErr != nil {
+				return nil, err
+			}
+			m.warn("index.stale_fallback")
+			body = stale
+		} else if err := os.MkdirAll(shared.GetCachePath(), os.ModePerm); err == nil {
+			os.WriteFile(cacheFile, body, 0644)
+		}
+	}
+
+	var versions []IndexEntry
+	if err := json.Unmarshal(body, &versions); err != nil {
+		return nil, i18n.Errorf("index.decode_error", err)
+	}
+
+	return versions, nil
+}
+
+// CachedIndex devuelve el índice guardado en la caché, aunque esté vencido, sin acceder al mirror
+func CachedIndex() ([]IndexEntry, error) {
+	body, err := os.ReadFile(IndexCachePath())
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []IndexEntry
+	if err := json.Unmarshal(body, &versions); err != nil {
+		return nil, i18n.Errorf("index.decode_error", err)
+	}
+	return versions, nil
+}
+
+// IndexCachePath devuelve la ruta del index.json guardado en la caché
+func IndexCachePath() string {
+	return filepath.Join(shared.GetCachePath(), "index.json")
+}
+
+// readCachedFile lee un archivo de la caché si no superó cache_ttl
+func readCachedFile(cacheFile string) ([]byte, error) {
+	info, err := os.Stat(cacheFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if time.Since(info.ModTime()) > shared.GetConfigDuration("cache_ttl") {
+		return nil, i18n.Errorf("index.expired")
+	}
+
+	return os.ReadFile(cacheFile)
+}
+
+// DownloadIndex descarga el contenido de index.json del mirror, sin usar la caché
+func (m *Manager) DownloadIndex() ([]byte, error) {
+	client, err := m.Client()
+	if err != nil {
+		return nil, err
+	}
+
+	jsonDataURL := shared.GetNodeRepositoryBaseURL() + "index.json"
+
+	req, err := http.NewRequest("GET", jsonDataURL, nil)
+	if err != nil {
+		return nil, i18n.Errorf("http.request_error", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, categorize(ErrNetwork, i18n.Errorf("index.request_error", err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, categorize(ErrNetwork, i18n.Errorf("index.status_error", resp.Status))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, i18n.Errorf("index.read_error", err)
+	}
+
+	return body, nil
+}
+
+/*
+Resolve convierte la versión indicada por el usuario en una versión completa:
+"lts" es la última versión LTS, "20" o "20.11" es la versión más nueva de esa línea
+según index.json, "20.11.0" (con o sin "v") se usa tal cual y un alias es la versión
+a la que apunta.
+*/
+func (m *Manager) Resolve(spec string) (string, error) {
+	spec = expandAlias(spec)
+	if spec == "lts" {
+		return m.latestLTS()
+	}
+
+	version := shared.NormalizeVersion(spec)
+	if strings.Count(version, ".") >= 2 {
+		return version, nil
+	}
+
+	versions, err := m.Index()
+	if err != nil {
+		return "", err
+	}
+
+	best := ""
+	var bestVersion shared.Version
+	for _, entry := range versions {
+		candidate := shared.NormalizeVersion(entry.Version)
+		if candidate != version && !strings.HasPrefix(candidate, version+".") {
+			continue
+		}
+		parsed, err := ParseVersion(candidate)
+		if err != nil {
+			continue
+		}
+		if best == "" || CompareVersions(parsed, bestVersion) > 0 {
+			best, bestVersion = candidate, parsed
+		}
+	}
+
+	if best == "" {
+		return "", categorize(ErrNotFound, i18n.Errorf("index.no_match", spec))
+	}
+	return best, nil
+}
+
+/*
+LatestInLine devuelve la versión más nueva publicada en la línea de una versión completa: la
+misma versión mayor o, con patchOnly, la misma versión menor. Puede ser la misma versión.
+*/
+func (m *Manager) LatestInLine(version string, patchOnly bool) (string, error) {
+	parsed, err := ParseVersion(shared.NormalizeVersion(version))
+	if err != nil {
+		return "", err
+	}
+
+	line := strconv.Itoa(parsed.Major)
+	if patchOnly {
+		line += "." + strconv.Itoa(parsed.Minor)
+	}
+	return m.Resolve(line)
+}
+
+// latestLTS devuelve la versión LTS más reciente según index.json
+func (m *Manager) latestLTS() (string, error) {
+	versions, err := m.Index()
+	if err != nil {
+		return "", err
+	}
+
+	// Devolver el primer elemento del índice (el más reciente) que pertenezca a una línea LTS
+	for _, entry := range versions {
+		if entry.LTSName() != "" {
+			return shared.NormalizeVersion(entry.Version), nil
+		}
+	}
+
+	return "", categorize(ErrNotFound, i18n.Errorf("index.no_lts"))
+}
@@ -0,0 +1,179 @@
+package manager
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"polynode/i18n"
+	"polynode/shared"
+	"sync"
+)
+
+/*
+Install instala una o varias versiones ("20", "20.11", "20.11.0" o "lts"). Las versiones se
+resuelven antes de empezar y las descargas se hacen en paralelo (hasta parallel_downloads a la
+vez). Devuelve un resultado por versión; el error indica cuántas no se pudieron instalar.
+*/
+func (m *Manager) Install(specs ...string) ([]InstallResult, error) {
+	if _, err := m.Client(); err != nil {
+		return nil, err
+	}
+
+	// Resolver todas las versiones antes de empezar las descargas
+	var results []*InstallResult
+	seen := map[string]bool{}
+	for _, spec := range specs {
+		result := &InstallResult{Spec: spec}
+		result.Version, result.Err = m.Resolve(spec)
+		if result.Err == nil {
+			if seen[result.Version] {
+				continue
+			}
+			seen[result.Version] = true
+			if result.Version != shared.NormalizeVersion(spec) {
+				m.event(Event{Kind: EventResolved, Spec: spec, Version: result.Version})
+			}
+		}
+		results = append(results, result)
+	}
+
+	parallel := shared.GetConfigInt("parallel_downloads")
+	if parallel < 1 {
+		parallel = 1
+	}
+	semaphore := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+
+	for _, result := range results {
+		if result.Err != nil {
+			continue
+		}
+		wg.Add(1)
+		go func(result *InstallResult) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			result.Cached, result.Err = m.installResolvedVersion(result.Version)
+			if result.Err == nil {
+				result.Path = shared.GetVersionPath(result.Version)
+			}
+		}(result)
+	}
+	wg.Wait()
+
+	failed := 0
+	installed := make([]InstallResult, len(results))
+	for i, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+		installed[i] = *result
+	}
+
+	if failed > 0 {
+		if len(results) == 1 {
+			return installed, installed[0].Err
+		}
+		return installed, i18n.Errorf("install.failed_count", failed, len(results))
+	}
+	return installed, nil
+}
+
+/*
+installResolvedVersion obtiene el archivo de una versión ya resuelta (de la caché de descargas
+o del mirror) y lo extrae en el repositorio. Devuelve si el archivo se tomó de la caché.
+*/
+func (m *Manager) installResolvedVersion(version string) (bool, error) {
+	archiveName := shared.GetArchiveName(version)
+
+	// El hash de SHASUMS256.txt identifica el archivo en la caché y permite verificar la descarga.
+	// Sin él no se instala la versión: buscar en la caché sólo por nombre o descargar sin
+	// verificar permitiría instalar un archivo modificado.
+	checksums, err := m.Checksums(version)
+	if err != nil {
+		return false, err
+	}
+	expectedSHA := checksums[archiveName]
+	if expectedSHA == "" {
+		return false, categorize(ErrIntegrity, i18n.Errorf("checksums.missing_file", ChecksumsFileName, archiveName))
+	}
+
+	archivePath, cached := FindCachedArchive(archiveName, expectedSHA)
+	if cached {
+		m.event(Event{Kind: EventCacheHit, Version: version, File: archiveName})
+	} else {
+		archivePath, err = m.DownloadArchive(version, archiveName, expectedSHA)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	// Crear el directorio de instalación si no existe
+	err = os.MkdirAll(shared.GetRepoPath(), os.ModePerm)
+	if err != nil {
+		return cached, i18n.Errorf("install.mkdir_error", err)
+	}
+
+	m.event(Event{Kind: EventExtract, Version: version, File: archiveName})
+
+	// Extraer el archivo descargado
+	err = ExtractArchive(archivePath, shared.GetRepoPath())
+	if err != nil {
+		return cached, i18n.Errorf("install.extract_error", err)
+	}
+
+	m.event(Event{Kind: EventInstalled, Version: version, File: archiveName})
+	return cached, nil
+}
+
+// DownloadArchive descarga un archivo de una versión desde el mirror y lo guarda en la caché
+func (m *Manager) DownloadArchive(version, fileName, expectedSHA string) (string, error) {
+	client, err := m.Client()
+	if err != nil {
+		return "", err
+	}
+
+	archiveURL := shared.GetNodeArchiveURL(version, fileName)
+	m.event(Event{Kind: EventDownload, Version: version, File: fileName, Message: archiveURL})
+
+	req, err := http.NewRequest("GET", archiveURL, nil)
+	if err != nil {
+		return "", i18n.Errorf("http.request_error", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", categorize(ErrNetwork, i18n.Errorf("install.download_error", fileName, err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", categorize(ErrNotFound, i18n.Errorf("install.version_not_found", version))
+	}
+
+	// Guardar el archivo en la caché informando el avance
+	reader := &progressReader{
+		reader:   resp.Body,
+		manager:  m,
+		progress: Progress{Version: version, File: fileName, Total: resp.ContentLength},
+	}
+	m.progress(reader.progress)
+
+	return StoreInCache(reader, fileName, expectedSHA)
+}
+
+// progressReader informa al Reporter el avance de la lectura de una descarga
+type progressReader struct {
+	reader   io.Reader
+	manager  *Manager
+	progress Progress
+}
+
+func (pr *progressReader) Read(p []byte) (int, error) {
+	n, err := pr.reader.Read(p)
+	if n > 0 {
+		pr.progress.Current += int64(n)
+		pr.manager.progress(pr.progress)
+	}
+	return n, err
+}
@@ -0,0 +1,165 @@
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"polynode/i18n"
+	"polynode/shared"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// List devuelve las versiones instaladas en el repositorio para la plataforma configurada, ordenadas
+func (m *Manager) List() ([]InstalledVersion, error) {
+	versions, err := ListInstalledVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	currentVersion := currentVersion()
+	installed := make([]InstalledVersion, len(versions))
+	for i, version := range versions {
+		installed[i] = InstalledVersion{
+			Version: version,
+			Path:    shared.GetVersionPath(version),
+			Current: version == currentVersion,
+		}
+	}
+	return installed, nil
+}
+
+// Current devuelve la versión seleccionada, o una cadena vacía si no hay ninguna
+func (m *Manager) Current() (string, error) {
+	return shared.CurrentVersion()
+}
+
+// currentVersion devuelve la versión seleccionada; si no se puede obtener, se considera que no hay ninguna
+func currentVersion() string {
+	version, _ := shared.CurrentVersion()
+	return version
+}
+
+// ListInstalledVersions devuelve los números de las versiones instaladas, de la más antigua a la más nueva
+func ListInstalledVersions() ([]string, error) {
+	// Obtener una lista de todos los directorios dentro de la carpeta de instalación
+	files, err := os.ReadDir(shared.GetRepoPath())
+	if err != nil {
+		return nil, i18n.Errorf("list.read_error", err)
+	}
+
+	var versions []string
+	for _, file := range files {
+		if file.IsDir() {
+			// Excluir la carpeta 'current' de la lista
+			if file.Name() != "current" {
+				// Verificar si el nombre del directorio corresponde a una versión de Node
+				if strings.HasPrefix(file.Name(), "node-v") {
+					// Obtener solo el número de versión, sin el sufijo de plataforma (por ejemplo 'win-x64')
+					version := strings.TrimPrefix(file.Name(), "node-v")
+					if !strings.HasSuffix(version, "-"+shared.GetPlatform()) {
+						continue
+					}
+					version = strings.TrimSuffix(version, "-"+shared.GetPlatform())
+					versions = append(versions, version)
+				}
+			}
+		}
+	}
+
+	// Convertir las versiones a una estructura personalizada y ordenarlas
+	var sortedVersions []string
+	for _, ver := range versions {
+		if _, err := ParseVersion(ver); err != nil {
+			continue
+		}
+		sortedVersions = append(sortedVersions, ver)
+	}
+	sort.Slice(sortedVersions, func(i, j int) bool {
+		v1, _ := ParseVersion(sortedVersions[i])
+		v2, _ := ParseVersion(sortedVersions[j])
+		return CompareVersions(v1, v2) < 0
+	})
+
+	return sortedVersions, nil
+}
+
+/*
+ResolveInstalled convierte la versión indicada por el usuario en una versión instalada: un alias
+es la versión a la que apunta, "20" o "20.11" es la versión instalada más nueva de esa línea y
+"20.11.0" (con o sin "v") debe estar instalada.
+*/
+func ResolveInstalled(spec string) (string, error) {
+	version := shared.NormalizeVersion(expandAlias(spec))
+	versions, err := ListInstalledVersions()
+	if err != nil {
+		return "", err
+	}
+
+	// Las versiones están ordenadas de la más antigua a la más nueva
+	best := ""
+	for _, installed := range versions {
+		if installed == version || strings.HasPrefix(installed, version+".") {
+			best = installed
+		}
+	}
+	if best == "" {
+		return "", categorize(ErrNotFound, i18n.Errorf("list.not_installed", spec))
+	}
+	return best, nil
+}
+
+// DirSize devuelve el tamaño total de los archivos de un directorio
+func DirSize(path string) (int64, error) {
+	var size int64
+	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+// CompareVersions compara dos versiones; el resultado es negativo, cero o positivo como en strings.Compare
+func CompareVersions(v1, v2 shared.Version) int {
+	if v1.Major != v2.Major {
+		return v1.Major - v2.Major
+	}
+	if v1.Minor != v2.Minor {
+		return v1.Minor - v2.Minor
+	}
+	return v1.Patch - v2.Patch
+}
+
+// ParseVersion interpreta una versión completa (mayor.menor.revisión)
+func ParseVersion(versionStr string) (shared.Version, error) {
+	parts := strings.Split(versionStr, ".")
+	if len(parts) != 3 {
+		return shared.Version{}, i18n.Errorf("version.invalid_format", versionStr)
+	}
+
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return shared.Version{}, i18n.Errorf("version.invalid_major", err)
+	}
+
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return shared.Version{}, i18n.Errorf("version.invalid_minor", err)
+	}
+
+	patch, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return shared.Version{}, i18n.Errorf("version.invalid_patch", err)
+	}
+
+	return shared.Version{
+		Major: major,
+		Minor: minor,
+		Patch: patch,
+	}, nil
+}
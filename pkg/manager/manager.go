@@ -0,0 +1,220 @@
+/*
+Package manager permite instalar, seleccionar y desinstalar versiones de Node desde otros
+programas, con la misma configuración y el mismo espacio de trabajo que la línea de comandos
+de polynode. Las operaciones devuelven resultados tipados y errores en lugar de imprimir en la
+consola; el avance se informa a través de un Reporter.
+
+	m, err := manager.Open(manager.Options{Workspace: "/opt/polynode"})
+	...
+	results, err := m.Install("20", "lts")
+	...
+	_, err = m.Use("20.11.0")
+*/
+package manager
+
+import (
+	"net/http"
+	"path/filepath"
+	"polynode/i18n"
+	"polynode/shared"
+	"sync"
+)
+
+// EventKind identifica el tipo de un evento informado al Reporter
+type EventKind string
+
+const (
+	// EventInfo es un mensaje informativo
+	EventInfo EventKind = "info"
+	// EventWarning es una advertencia que no interrumpe la operación
+	EventWarning EventKind = "warning"
+	// EventResolved indica que una versión parcial ("20", "lts") se resolvió a una versión completa
+	EventResolved EventKind = "resolved"
+	// EventCacheHit indica que el archivo de una versión se toma de la caché de descargas
+	EventCacheHit EventKind = "cache-hit"
+	// EventDownload indica que comienza la descarga de un archivo; Message contiene la URL
+	EventDownload EventKind = "download"
+	// EventExtract indica que comienza la extracción de un archivo
+	EventExtract EventKind = "extract"
+	// EventInstalled indica que una versión quedó instalada
+	EventInstalled EventKind = "installed"
+)
+
+// Event es una notificación sobre el avance de una operación
+type Event struct {
+	Kind    EventKind
+	Spec    string
+	Version string
+	File    string
+	Message string
+}
+
+// Progress informa el avance de una descarga; Total es -1 si el servidor no indicó el tamaño
+type Progress struct {
+	Version string
+	File    string
+	Current int64
+	Total   int64
+}
+
+/*
+Reporter recibe los eventos y el avance de las operaciones del Manager. Con varias instalaciones
+en paralelo los métodos se llaman desde distintas goroutines, por lo que deben ser seguros
+para uso concurrente.
+*/
+type Reporter interface {
+	Event(event Event)
+	Progress(progress Progress)
+}
+
+// ReporterFuncs adapta un par de funciones a la interfaz Reporter; las funciones nil se ignoran
+type ReporterFuncs struct {
+	OnEvent    func(Event)
+	OnProgress func(Progress)
+}
+
+func (r ReporterFuncs) Event(event Event) {
+	if r.OnEvent != nil {
+		r.OnEvent(event)
+	}
+}
+
+func (r ReporterFuncs) Progress(progress Progress) {
+	if r.OnProgress != nil {
+		r.OnProgress(progress)
+	}
+}
+
+// Options configura un Manager
+type Options struct {
+	// HTTPClient es el cliente usado para las descargas. Si es nil se usa ClientFactory.
+	HTTPClient *http.Client
+	// ClientFactory crea el cliente la primera vez que se necesita. Si es nil se usa NewHTTPClient,
+	// que aplica la configuración de proxy y TLS de polynode.
+	ClientFactory func() (*http.Client, error)
+	// Reporter recibe los eventos y el avance de las operaciones. Puede ser nil.
+	Reporter Reporter
+	// Workspace es el directorio del espacio de trabajo que usa Open. Si está vacío se usa
+	// POLYNODE_PATH o, si no está definida, el directorio por defecto.
+	Workspace string
+}
+
+// Manager administra las versiones de Node del espacio de trabajo de polynode
+type Manager struct {
+	options  Options
+	clientMu sync.Mutex
+	client   *http.Client
+}
+
+// InstallResult es el resultado de la instalación de una versión
+type InstallResult struct {
+	// Spec es la versión tal como se pidió (por ejemplo "20" o "lts")
+	Spec string
+	// Version es la versión completa resuelta; vacía si no se pudo resolver
+	Version string
+	// Path es el directorio de la versión en el repositorio
+	Path string
+	// Cached indica que el archivo se tomó de la caché de descargas
+	Cached bool
+	Err    error
+}
+
+// InstalledVersion es una versión instalada en el repositorio
+type InstalledVersion struct {
+	Version string
+	Path    string
+	Current bool
+}
+
+// UseResult es el resultado de seleccionar una versión
+type UseResult struct {
+	Version string
+	// Previous es la versión seleccionada antes del cambio; vacía si no había ninguna
+	Previous string
+	// Installed indica que la versión se instaló automáticamente (auto_install)
+	Installed bool
+	// SecurityFixes son las versiones de seguridad posteriores de la misma línea según el índice guardado
+	SecurityFixes []string
+}
+
+// UninstallResult es el resultado de desinstalar una versión
+type UninstallResult struct {
+	Version string
+	// WasCurrent indica que la versión desinstalada era la seleccionada
+	WasCurrent bool
+}
+
+/*
+New crea un Manager con las opciones indicadas, usando la configuración ya cargada. La línea de
+comandos lee la configuración al iniciar; los demás programas deben usar Open.
+*/
+func New(options Options) *Manager {
+	return &Manager{options: options, client: options.HTTPClient}
+}
+
+/*
+Open selecciona el espacio de trabajo de Options.Workspace, lee su configuración (config.json y
+el .polynode.json del proyecto del directorio actual) y crea un Manager, igual que la línea de
+comandos al iniciar. Las variables de entorno con valores inválidos se ignoran y se informan
+como advertencias al Reporter.
+*/
+func Open(options Options) (*Manager, error) {
+	if options.Workspace != "" {
+		workspace, err := filepath.Abs(options.Workspace)
+		if err != nil {
+			return nil, i18n.Errorf("config.invalid_workspace", err)
+		}
+		shared.SetInstallPath(workspace)
+	}
+	if err := shared.LoadConfig(); err != nil {
+		return nil, err
+	}
+	i18n.SetLanguage(shared.GetConfig("lang"))
+
+	m := New(options)
+	for _, warning := range shared.ConfigWarnings() {
+		m.event(Event{Kind: EventWarning, Message: warning})
+	}
+	return m, nil
+}
+
+// Client devuelve el cliente HTTP de las descargas, creándolo la primera vez que se usa
+func (m *Manager) Client() (*http.Client, error) {
+	m.clientMu.Lock()
+	defer m.clientMu.Unlock()
+
+	if m.client != nil {
+		return m.client, nil
+	}
+
+	factory := m.options.ClientFactory
+	if factory == nil {
+		factory = NewHTTPClient
+	}
+	client, err := factory()
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return nil, i18n.Errorf("http.client_error")
+	}
+	m.client = client
+	return client, nil
+}
+
+func (m *Manager) event(event Event) {
+	if m.options.Reporter != nil {
+		m.options.Reporter.Event(event)
+	}
+}
+
+// warn informa una advertencia con el mensaje del catálogo indicado por key
+func (m *Manager) warn(key string, args ...interface{}) {
+	m.event(Event{Kind: EventWarning, Message: i18n.T(key, args...)})
+}
+
+func (m *Manager) progress(progress Progress) {
+	if m.options.Reporter != nil {
+		m.options.Reporter.Progress(progress)
+	}
+}
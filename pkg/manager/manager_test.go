@@ -0,0 +1,44 @@
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"polynode/shared"
+	"testing"
+)
+
+func TestOpenLoadsWorkspaceConfig(t *testing.T) {
+	workspace := t.TempDir()
+	config := `{"version": 1, "settings": {"mirror": "https://mirror.example.com/dist/"}}`
+	if err := os.WriteFile(filepath.Join(workspace, "config.json"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("POLYNODE_MIRROR", "")
+	t.Setenv("POLYNODE_TIMEOUT", "no es una duración")
+
+	var warnings []string
+	_, err := Open(Options{
+		Workspace: workspace,
+		Reporter: ReporterFuncs{OnEvent: func(event Event) {
+			if event.Kind == EventWarning {
+				warnings = append(warnings, event.Message)
+			}
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if shared.GetInstallPath() != workspace {
+		t.Errorf("espacio de trabajo %s, se esperaba %s", shared.GetInstallPath(), workspace)
+	}
+	if mirror := shared.GetConfig("mirror"); mirror != "https://mirror.example.com/dist/" {
+		t.Errorf("mirror %s, no se leyó config.json", mirror)
+	}
+	if timeout, source := shared.GetConfigValue("timeout"); source != shared.SourceDefault {
+		t.Errorf("timeout %s (%s), se esperaba el valor por defecto", timeout, source)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("se esperaba una advertencia por POLYNODE_TIMEOUT, se obtuvo %q", warnings)
+	}
+}
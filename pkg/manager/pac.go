@@ -0,0 +1,86 @@
+package manager
+
+import (
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"polynode/i18n"
+	"polynode/pac"
+	"polynode/shared"
+	"strings"
+)
+
+/*
+loadPACScript obtiene el archivo PAC indicado en proxy_pac, que puede ser una URL
+http(s)://, una URL file:// o una ruta local. La descarga del PAC se hace sin proxy.
+*/
+func loadPACScript(location string, tlsConfig *tls.Config) (*pac.Script, error) {
+	var src []byte
+	var err error
+
+	switch {
+	case strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://"):
+		client := &http.Client{
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+			Timeout:   shared.GetConfigDuration("timeout"),
+		}
+		resp, err := client.Get(location)
+		if err != nil {
+			return nil, i18n.Errorf("pac.download_error", location, err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, i18n.Errorf("pac.download_status_error", location, resp.Status)
+		}
+		if src, err = io.ReadAll(resp.Body); err != nil {
+			return nil, i18n.Errorf("pac.read_error", location, err)
+		}
+
+	case strings.HasPrefix(location, "file://"):
+		fileURL, err := url.Parse(location)
+		if err != nil {
+			return nil, i18n.Errorf("pac.invalid_url", err)
+		}
+		// En Windows las URL file:///c:/... generan una ruta con "/" inicial
+		path := fileURL.Path
+		if len(path) > 2 && path[0] == '/' && path[2] == ':' {
+			path = path[1:]
+		}
+		if src, err = os.ReadFile(path); err != nil {
+			return nil, i18n.Errorf("pac.read_error", path, err)
+		}
+
+	default:
+		if src, err = os.ReadFile(location); err != nil {
+			return nil, i18n.Errorf("pac.read_error", location, err)
+		}
+	}
+
+	return pac.Parse(string(src))
+}
+
+// pacProxyFunc elige el proxy de cada solicitud evaluando FindProxyForURL del archivo PAC
+func pacProxyFunc(script *pac.Script, credentials shared.Credentials) func(*http.Request) (*url.URL, error) {
+	return func(req *http.Request) (*url.URL, error) {
+		result, err := script.FindProxyForURL(req.URL.String(), req.URL.Hostname())
+		if err != nil {
+			return nil, err
+		}
+
+		// Se usa la primera alternativa soportada por el transporte HTTP
+		for _, proxy := range pac.ParseResult(result) {
+			proxyURL, err := proxy.URL()
+			if err != nil {
+				continue
+			}
+			if proxyURL != nil {
+				addProxyCredentials(proxyURL, credentials)
+			}
+			return proxyURL, nil
+		}
+
+		return nil, i18n.Errorf("pac.unsupported_result", result)
+	}
+}
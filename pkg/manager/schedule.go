@@ -0,0 +1,127 @@
+package manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"polynode/i18n"
+	"polynode/shared"
+	"time"
+)
+
+// Estados de una línea de versiones según el calendario de publicaciones
+const (
+	StatusCurrent     = "current"
+	StatusLTS         = "lts"
+	StatusMaintenance = "maintenance"
+	StatusEOL         = "eol"
+	StatusUnknown     = "unknown"
+)
+
+// scheduleDateLayout es el formato de las fechas de schedule.json
+const scheduleDateLayout = "2006-01-02"
+
+// ReleaseLine es una línea de versiones ("v20") en el calendario de publicaciones de Node (schedule.json)
+type ReleaseLine struct {
+	Start       string `json:"start"`
+	LTS         string `json:"lts,omitempty"`
+	Maintenance string `json:"maintenance,omitempty"`
+	End         string `json:"end"`
+	Codename    string `json:"codename,omitempty"`
+}
+
+/*
+Status devuelve el estado de la línea en una fecha: "current" hasta que pasa a LTS, "lts" hasta
+que pasa a mantenimiento, "maintenance" hasta la fecha de fin y "eol" a partir de esa fecha.
+*/
+func (l ReleaseLine) Status(at time.Time) string {
+	end, err := time.Parse(scheduleDateLayout, l.End)
+	if err != nil {
+		return StatusUnknown
+	}
+	if !at.Before(end) {
+		return StatusEOL
+	}
+	if maintenance, err := time.Parse(scheduleDateLayout, l.Maintenance); err == nil && !at.Before(maintenance) {
+		return StatusMaintenance
+	}
+	if lts, err := time.Parse(scheduleDateLayout, l.LTS); err == nil && !at.Before(lts) {
+		return StatusLTS
+	}
+	return StatusCurrent
+}
+
+// ReleaseLineName devuelve la clave de schedule.json de una versión: "v20", o "v0.12" antes de Node 1
+func ReleaseLineName(version shared.Version) string {
+	if version.Major == 0 {
+		return fmt.Sprintf("v0.%d", version.Minor)
+	}
+	return fmt.Sprintf("v%d", version.Major)
+}
+
+/*
+Schedule obtiene el calendario de publicaciones de Node de schedule_url, indexado por línea ("v20").
+Como el índice de versiones, se guarda en la caché y se reutiliza mientras no supere cache_ttl;
+si no se puede descargar, se usa el calendario guardado aunque esté vencido.
+*/
+func (m *Manager) Schedule() (map[string]ReleaseLine, error) {
+	cacheFile := ScheduleCachePath()
+
+	body, err := readCachedFile(cacheFile)
+	if err != nil {
+		body, err = m.DownloadSchedule()
+		if err != nil {
+			stale, staleErr := os.ReadFile(cacheFile)
+			if staleErr != nil {
+				return nil, err
+			}
+			m.warn("schedule.stale_fallback")
+			body = stale
+		} else if err := os.MkdirAll(shared.GetCachePath(), os.ModePerm); err == nil {
+			os.WriteFile(cacheFile, body, 0644)
+		}
+	}
+
+	var schedule map[string]ReleaseLine
+	if err := json.Unmarshal(body, &schedule); err != nil {
+		return nil, i18n.Errorf("schedule.decode_error", err)
+	}
+	return schedule, nil
+}
+
+// ScheduleCachePath devuelve la ruta del schedule.json guardado en la caché
+func ScheduleCachePath() string {
+	return filepath.Join(shared.GetCachePath(), "schedule.json")
+}
+
+// DownloadSchedule descarga el calendario de publicaciones de schedule_url, sin usar la caché
+func (m *Manager) DownloadSchedule() ([]byte, error) {
+	client, err := m.Client()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", shared.GetConfig("schedule_url"), nil)
+	if err != nil {
+		return nil, i18n.Errorf("http.request_error", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, categorize(ErrNetwork, i18n.Errorf("schedule.request_error", err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, categorize(ErrNetwork, i18n.Errorf("schedule.status_error", resp.Status))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, i18n.Errorf("schedule.read_error", err)
+	}
+	return body, nil
+}
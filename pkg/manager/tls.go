@@ -0,0 +1,81 @@
+package manager
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"polynode/i18n"
+	"polynode/shared"
+	"strings"
+)
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+/*
+buildTLSConfig arma la configuración TLS de las descargas a partir de la configuración:
+certificados raíz adicionales (ca_file, NODE_EXTRA_CA_CERTS y SSL_CERT_FILE), certificado
+de cliente para TLS mutuo, versión mínima de TLS y la opción insecure para diagnóstico.
+*/
+func buildTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tlsVersions[shared.GetConfig("tls_min_version")],
+	}
+
+	caFiles := caBundleFiles()
+	if len(caFiles) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+
+		for _, caFile := range caFiles {
+			pem, err := os.ReadFile(caFile)
+			if err != nil {
+				return nil, i18n.Errorf("tls.ca_read_error", caFile, err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, i18n.Errorf("tls.ca_invalid", caFile)
+			}
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	clientCert := shared.GetConfig("client_cert")
+	clientKey := shared.GetConfig("client_key")
+	if clientCert != "" || clientKey != "" {
+		if clientCert == "" || clientKey == "" {
+			return nil, i18n.Errorf("tls.client_cert_incomplete")
+		}
+		certificate, err := tls.LoadX509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, i18n.Errorf("tls.client_cert_error", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	if shared.GetConfigBool("insecure") {
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	return tlsConfig, nil
+}
+
+// caBundleFiles devuelve los archivos PEM de certificados raíz adicionales a cargar
+func caBundleFiles() []string {
+	var files []string
+	for _, value := range []string{shared.GetConfig("ca_file"), os.Getenv("NODE_EXTRA_CA_CERTS"), os.Getenv("SSL_CERT_FILE")} {
+		for _, file := range filepath.SplitList(value) {
+			file = strings.TrimSpace(file)
+			if file != "" {
+				files = append(files, file)
+			}
+		}
+	}
+	return files
+}
@@ -0,0 +1,37 @@
+package manager
+
+import (
+	"os"
+	"polynode/i18n"
+	"polynode/shared"
+)
+
+// Uninstall elimina una versión del repositorio (o la versión de un alias); si es la versión actual, también elimina current
+func (m *Manager) Uninstall(version string) (UninstallResult, error) {
+	version = shared.NormalizeVersion(expandAlias(version))
+	result := UninstallResult{Version: version}
+	versionDir := shared.GetVersionPath(version)
+
+	// Verificar si la versión que se intenta desinstalar está instalada
+	if _, err := os.Stat(versionDir); os.IsNotExist(err) {
+		return result, categorize(ErrNotFound, i18n.Errorf("uninstall.not_installed", version))
+	}
+
+	// Obtener la versión actual antes de eliminar, ya que current puede ser un enlace a versionDir
+	currentVersion := currentVersion()
+
+	// Eliminar el directorio de la versión
+	if err := os.RemoveAll(versionDir); err != nil {
+		return result, i18n.Errorf("uninstall.error", version, err)
+	}
+
+	// Si la versión desinstalada es la misma que la actual, borrar el directorio "current"
+	if currentVersion == version {
+		result.WasCurrent = true
+		if err := os.RemoveAll(shared.GetCurrentVersionPath()); err != nil {
+			return result, i18n.Errorf("uninstall.current_error", err)
+		}
+	}
+
+	return result, forgetUse(version)
+}
@@ -0,0 +1,96 @@
+package manager
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"polynode/i18n"
+	"polynode/shared"
+	"time"
+)
+
+const usageFileVersion = 1
+
+// usageFile es el contenido de usage.json: la última vez que se usó cada versión
+type usageFile struct {
+	Version  int                  `json:"version"`
+	LastUsed map[string]time.Time `json:"lastUsed"`
+}
+
+// UsagePath devuelve la ruta del archivo con la fecha de último uso de cada versión
+func UsagePath() string {
+	return filepath.Join(shared.GetInstallPath(), "usage.json")
+}
+
+// RecordUse guarda la fecha actual como la última vez que se usó una versión
+func RecordUse(version string) error {
+	file, err := readUsage()
+	if err != nil {
+		return err
+	}
+	file.LastUsed[version] = time.Now().UTC()
+	return writeUsage(file)
+}
+
+/*
+LastUsed devuelve la última vez que se usó una versión según usage.json (la escriben Use y
+RecordUse). Si nunca se usó, devuelve la fecha de instalación, es decir, la fecha de
+modificación del directorio de la versión.
+*/
+func LastUsed(version string) (time.Time, error) {
+	file, err := readUsage()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if lastUsed, ok := file.LastUsed[version]; ok {
+		return lastUsed, nil
+	}
+
+	info, err := os.Stat(shared.GetVersionPath(version))
+	if err != nil {
+		return time.Time{}, categorize(ErrNotFound, i18n.Errorf("list.not_installed", version))
+	}
+	return info.ModTime(), nil
+}
+
+// forgetUse elimina la fecha de último uso de una versión desinstalada
+func forgetUse(version string) error {
+	file, err := readUsage()
+	if err != nil {
+		return err
+	}
+	if _, ok := file.LastUsed[version]; !ok {
+		return nil
+	}
+	delete(file.LastUsed, version)
+	return writeUsage(file)
+}
+
+func readUsage() (*usageFile, error) {
+	file := &usageFile{Version: usageFileVersion, LastUsed: map[string]time.Time{}}
+	data, err := os.ReadFile(UsagePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return file, nil
+		}
+		return nil, i18n.Errorf("usage.read_error", UsagePath(), err)
+	}
+	if err := json.Unmarshal(data, file); err != nil {
+		return nil, i18n.Errorf("usage.decode_error", UsagePath(), err)
+	}
+	if file.LastUsed == nil {
+		file.LastUsed = map[string]time.Time{}
+	}
+	return file, nil
+}
+
+func writeUsage(file *usageFile) error {
+	data, err := json.MarshalIndent(file, "", "    ")
+	if err != nil {
+		return i18n.Errorf("usage.write_error", err)
+	}
+	if err := os.WriteFile(UsagePath(), data, 0644); err != nil {
+		return i18n.Errorf("usage.write_error", err)
+	}
+	return nil
+}
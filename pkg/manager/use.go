@@ -0,0 +1,199 @@
+package manager
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"polynode/i18n"
+	"polynode/shared"
+)
+
+/*
+Use selecciona una versión instalada (o la versión de un alias) como versión actual. Si la
+versión no está instalada y auto_install está activado, se instala antes de seleccionarla.
+Según link_mode, current es una copia del directorio de la versión o un enlace simbólico a él.
+*/
+func (m *Manager) Use(version string) (UseResult, error) {
+	version = shared.NormalizeVersion(expandAlias(version))
+	result := UseResult{Version: version}
+	versionPath := shared.GetVersionPath(version)
+
+	// Verificar si la carpeta de la versión de Node existe
+	_, err := os.Stat(versionPath)
+	if err != nil {
+		if !shared.GetConfigBool("auto_install") {
+			return result, categorize(ErrNotFound, i18n.Errorf("use.not_installed", version))
+		}
+
+		// Instalar la versión automáticamente según la configuración auto_install
+		m.event(Event{Kind: EventInfo, Version: version, Message: i18n.T("use.auto_install", version)})
+		results, err := m.Install(version)
+		if err != nil {
+			return result, err
+		}
+		// Una versión parcial ("20") o un alias de canal ("lts") se resuelve al instalarla
+		version = results[0].Version
+		result.Version = version
+		versionPath = shared.GetVersionPath(version)
+		result.Installed = true
+	}
+
+	// Las correcciones de seguridad se buscan en el índice guardado, sin acceder al mirror
+	if index, err := CachedIndex(); err == nil {
+		for _, fix := range SecurityFixes(index, version) {
+			result.SecurityFixes = append(result.SecurityFixes, shared.NormalizeVersion(fix.Version))
+		}
+	}
+
+	// Leer la versión actualmente seleccionada
+	currentVersion := currentVersion()
+	result.Previous = currentVersion
+
+	// Comprobar si la versión solicitada es la misma que la actual
+	if currentVersion == version {
+		return result, i18n.Errorf("use.already_selected", version)
+	}
+
+	if IsCurrentLinked() {
+		// Si current es un enlace, la versión anterior ya está en el repositorio
+		if err := os.Remove(shared.GetCurrentVersionPath()); err != nil {
+			return result, i18n.Errorf("use.remove_link_error", err)
+		}
+	} else if currentVersion != "" {
+		// Mover la versión anterior si es necesario
+		err = movePrevious(version)
+		if err != nil {
+			return result, i18n.Errorf("use.move_previous_error", err)
+		}
+	}
+
+	// Eliminar el directorio actual si ya existe
+	if _, err := os.Stat(shared.GetCurrentVersionPath()); !os.IsNotExist(err) {
+		os.RemoveAll(shared.GetCurrentVersionPath())
+	}
+
+	if shared.GetConfig("link_mode") == "symlink" {
+		// Crear un enlace simbólico de current al directorio de la versión
+		if err := os.Symlink(versionPath, shared.GetCurrentVersionPath()); err != nil {
+			return result, i18n.Errorf("use.symlink_error", err)
+		}
+	} else {
+		// Copiar el contenido completo del directorio de la versión a current
+		err = CopyDir(versionPath, shared.GetCurrentVersionPath())
+		if err != nil {
+			return result, i18n.Errorf("use.copy_error", err)
+		}
+	}
+
+	// La fecha de último uso no impide seleccionar la versión
+	if err := RecordUse(version); err != nil {
+		m.warn("usage.record_warning", err)
+	}
+	return result, nil
+}
+
+// IsCurrentLinked indica si current es un enlace simbólico (link_mode symlink)
+func IsCurrentLinked() bool {
+	info, err := os.Lstat(shared.GetCurrentVersionPath())
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeSymlink != 0
+}
+
+func movePrevious(version string) error {
+	currentVersion := currentVersion()
+
+	// Verificar si la versión actual es diferente
+	if version != "" && currentVersion != version {
+		// Eliminar la carpeta de la versión anterior si existe
+		previousPath := shared.GetVersionPath(currentVersion)
+		if _, err := os.Stat(previousPath); !os.IsNotExist(err) {
+			os.RemoveAll(previousPath)
+		}
+
+		// Renombrar current con el nombre de la versión anterior
+		err := os.Rename(shared.GetCurrentVersionPath(), previousPath)
+		if err != nil {
+			return i18n.Errorf("use.rename_current_error", err)
+		}
+	}
+
+	return nil
+}
+
+// CopyDir copia un directorio completo, conservando los permisos de los archivos y los enlaces simbólicos
+func CopyDir(src, dst string) error {
+	srcInfo, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dst, srcInfo.Mode()); err != nil {
+		return err
+	}
+
+	dir, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+
+	files, err := dir.Readdir(-1)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		srcFilePath := filepath.Join(src, file.Name())
+		dstFilePath := filepath.Join(dst, file.Name())
+
+		// Readdir no sigue los enlaces: se recrean para que, por ejemplo, bin/npm siga apuntando al paquete
+		switch {
+		case file.Mode()&os.ModeSymlink != 0:
+			if err := copySymlink(srcFilePath, dstFilePath); err != nil {
+				return err
+			}
+		case file.IsDir():
+			if err := CopyDir(srcFilePath, dstFilePath); err != nil {
+				return err
+			}
+		default:
+			if err := copyFile(srcFilePath, dstFilePath, file.Mode()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func copyFile(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm())
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func copySymlink(src, dst string) error {
+	target, err := os.Readlink(src)
+	if err != nil {
+		return err
+	}
+	os.Remove(dst)
+	return os.Symlink(target, dst)
+}
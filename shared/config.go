@@ -0,0 +1,488 @@
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"polynode/i18n"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	configFileName        = "config.json"
+	projectConfigFileName = ".polynode.json"
+	legacyProxyFileName   = "proxy.json"
+	configFormatVersion   = 1
+	envPrefix             = "POLYNODE_"
+)
+
+// Orígenes posibles de un valor de configuración, de mayor a menor prioridad
+const (
+	SourceFlag    = "flag"
+	SourceEnv     = "env"
+	SourceProject = "project"
+	SourceUser    = "user"
+	SourceDefault = "default"
+)
+
+/*
+ConfigKey describe una clave de configuración soportada. Las claves sensibles cambian de dónde
+y cómo se descargan las versiones, por lo que no se aceptan desde la configuración de un proyecto.
+Las claves booleanas pueden indicarse como flag sin valor (--insecure equivale a --insecure=true).
+*/
+type ConfigKey struct {
+	Name      string
+	Default   string
+	Validate  func(value string) error
+	Sensitive bool
+	Bool      bool
+}
+
+// ConfigFile es la estructura del archivo config.json (y de .polynode.json en un proyecto)
+type ConfigFile struct {
+	Version  int               `json:"version"`
+	Settings map[string]string `json:"settings"`
+}
+
+var configKeys = []ConfigKey{
+	{Name: "mirror", Default: nodeRemoteRepositoryBaseURL, Validate: validateURL, Sensitive: true},
+	{Name: "http_proxy", Default: "", Validate: validateOptionalURL, Sensitive: true},
+	{Name: "https_proxy", Default: "", Validate: validateOptionalURL, Sensitive: true},
+	{Name: "no_proxy", Default: "", Validate: validateAny, Sensitive: true},
+	{Name: "proxy_pac", Default: "", Validate: validateAny, Sensitive: true},
+	{Name: "ca_file", Default: "", Validate: validateAny, Sensitive: true},
+	{Name: "client_cert", Default: "", Validate: validateAny, Sensitive: true},
+	{Name: "client_key", Default: "", Validate: validateAny, Sensitive: true},
+	{Name: "tls_min_version", Default: "1.2", Validate: validateOneOf("1.0", "1.1", "1.2", "1.3"), Sensitive: true},
+	{Name: "insecure", Default: "false", Validate: validateBool, Sensitive: true, Bool: true},
+	{Name: "parallel_downloads", Default: "3", Validate: validatePositiveInt},
+	{Name: "arch", Default: "x64", Validate: validateOneOf("x64", "x86", "arm64")},
+	{Name: "cache_ttl", Default: "1h", Validate: validateDuration},
+	{Name: "cache_max_size", Default: "5GB", Validate: validateSize},
+	{Name: "timeout", Default: "30s", Validate: validateDuration},
+	{Name: "lang", Default: "", Validate: validateOneOf("es", "en")},
+	{Name: "auto_install", Default: "false", Validate: validateBool, Bool: true},
+	{Name: "backup_dir", Default: "", Validate: validateAny},
+	{Name: "backup_format", Default: "zip", Validate: validateOneOf("zip", "tar.gz", "tar.zst")},
+	{Name: "link_mode", Default: "copy", Validate: validateOneOf("copy", "symlink")},
+	{Name: "default_packages", Default: "", Validate: validateAny, Sensitive: true},
+	{Name: "schedule_url", Default: nodeScheduleURL, Validate: validateURL, Sensitive: true},
+}
+
+var (
+	flagValues        = map[string]string{}
+	userConfig        = newConfigFile()
+	projectConfig     = newConfigFile()
+	projectConfigPath string
+)
+
+func newConfigFile() *ConfigFile {
+	return &ConfigFile{Version: configFormatVersion, Settings: map[string]string{}}
+}
+
+// ConfigKeys devuelve la lista de claves de configuración soportadas
+func ConfigKeys() []ConfigKey {
+	return configKeys
+}
+
+// LookupConfigKey busca la definición de una clave de configuración por nombre
+func LookupConfigKey(name string) (ConfigKey, bool) {
+	for _, key := range configKeys {
+		if key.Name == name {
+			return key, true
+		}
+	}
+	return ConfigKey{}, false
+}
+
+// Description devuelve la descripción de la clave en el idioma seleccionado
+func (k ConfigKey) Description() string {
+	return i18n.T("config.key." + k.Name)
+}
+
+// EnvName devuelve el nombre de la variable de entorno que sobrescribe la clave
+func (k ConfigKey) EnvName() string {
+	return envPrefix + strings.ToUpper(k.Name)
+}
+
+// FlagName devuelve el nombre del flag de línea de comandos que sobrescribe la clave
+func (k ConfigKey) FlagName() string {
+	return "--" + strings.ReplaceAll(k.Name, "_", "-")
+}
+
+func GetConfigFilePath() string {
+	return filepath.Join(installPath, configFileName)
+}
+
+func GetProjectConfigPath() string {
+	return projectConfigPath
+}
+
+/*
+LoadConfig lee la configuración del usuario (config.json en el espacio de trabajo)
+y la del proyecto (.polynode.json en el directorio actual o alguno de sus padres).
+Si existe un proxy.json de versiones anteriores, se migra a config.json.
+*/
+func LoadConfig() error {
+	userConfig = newConfigFile()
+	if err := readConfigFile(GetConfigFilePath(), userConfig); err != nil {
+		return err
+	}
+
+	if err := migrateLegacyProxy(); err != nil {
+		return err
+	}
+
+	projectConfig = newConfigFile()
+	projectConfigPath = findProjectConfig()
+	if projectConfigPath != "" {
+		if err := readConfigFile(projectConfigPath, projectConfig); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readConfigFile(path string, cfg *ConfigFile) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return i18n.Errorf("config.read_error", path, err)
+	}
+
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return i18n.Errorf("config.decode_error", path, err)
+	}
+
+	if cfg.Version > configFormatVersion {
+		return i18n.Errorf("config.unsupported_version", path, cfg.Version)
+	}
+	cfg.Version = configFormatVersion
+	if cfg.Settings == nil {
+		cfg.Settings = map[string]string{}
+	}
+
+	return nil
+}
+
+func saveUserConfig() error {
+	data, err := json.MarshalIndent(userConfig, "", "    ")
+	if err != nil {
+		return i18n.Errorf("config.serialize_error", err)
+	}
+
+	if err := os.WriteFile(GetConfigFilePath(), data, 0644); err != nil {
+		return i18n.Errorf("config.write_error", err)
+	}
+
+	return nil
+}
+
+func migrateLegacyProxy() error {
+	legacyFile := filepath.Join(installPath, legacyProxyFileName)
+	data, err := os.ReadFile(legacyFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return i18n.Errorf("config.legacy_open_error", legacyProxyFileName, err)
+	}
+
+	proxyConfig := ProxyConfig{}
+	if err := json.Unmarshal(data, &proxyConfig); err != nil {
+		return i18n.Errorf("config.legacy_decode_error", legacyProxyFileName, err)
+	}
+
+	// Los valores ya presentes en config.json tienen prioridad sobre los del archivo anterior
+	if _, ok := userConfig.Settings["http_proxy"]; !ok && proxyConfig.HTTPProxy != "" {
+		userConfig.Settings["http_proxy"] = proxyConfig.HTTPProxy
+	}
+	if _, ok := userConfig.Settings["https_proxy"]; !ok && proxyConfig.HTTPSProxy != "" {
+		userConfig.Settings["https_proxy"] = proxyConfig.HTTPSProxy
+	}
+
+	if err := saveUserConfig(); err != nil {
+		return err
+	}
+
+	// Conservar el archivo anterior como respaldo
+	if err := os.Rename(legacyFile, legacyFile+".bak"); err != nil {
+		return i18n.Errorf("config.legacy_rename_error", legacyProxyFileName, err)
+	}
+
+	fmt.Print(i18n.T("config.legacy_migrated", legacyProxyFileName, configFileName))
+	return nil
+}
+
+func findProjectConfig() string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+
+	for {
+		candidate := filepath.Join(dir, projectConfigFileName)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// SetFlagValue registra un valor indicado por línea de comandos, que tiene la mayor prioridad
+func SetFlagValue(name, value string) error {
+	key, ok := LookupConfigKey(name)
+	if !ok {
+		return i18n.Errorf("config.unknown_key", name)
+	}
+	if err := key.Validate(value); err != nil {
+		return i18n.Errorf("config.invalid_value", name, err)
+	}
+	flagValues[name] = value
+	return nil
+}
+
+/*
+GetConfigValue devuelve el valor efectivo de una clave y su origen.
+El orden de precedencia es: flag > variable de entorno > proyecto > usuario > valor por defecto.
+*/
+func GetConfigValue(name string) (string, string) {
+	key, ok := LookupConfigKey(name)
+	if !ok {
+		return "", ""
+	}
+
+	if value, ok := flagValues[name]; ok {
+		return value, SourceFlag
+	}
+	// Los valores inválidos, y los sensibles del proyecto, se ignoran; ConfigWarnings los informa
+	if value, ok := os.LookupEnv(key.EnvName()); ok && value != "" && key.Validate(value) == nil {
+		return value, SourceEnv
+	}
+	if value, ok := projectConfig.Settings[name]; ok && !key.Sensitive && key.Validate(value) == nil {
+		return value, SourceProject
+	}
+	if value, ok := userConfig.Settings[name]; ok && key.Validate(value) == nil {
+		return value, SourceUser
+	}
+	return key.Default, SourceDefault
+}
+
+/*
+ConfigWarnings devuelve los avisos sobre los valores de configuración que se ignoran: los
+inválidos (en variables de entorno, config.json o .polynode.json) y las claves sensibles
+indicadas en .polynode.json.
+*/
+func ConfigWarnings() []string {
+	var warnings []string
+	for _, key := range configKeys {
+		if value := os.Getenv(key.EnvName()); value != "" && key.Validate(value) != nil {
+			warnings = append(warnings, i18n.T("config.env_invalid_ignored", key.EnvName(), value))
+		}
+		if value, ok := projectConfig.Settings[key.Name]; ok {
+			if key.Sensitive {
+				warnings = append(warnings, i18n.T("config.project_sensitive_ignored", key.Name, projectConfigPath))
+			} else if key.Validate(value) != nil {
+				warnings = append(warnings, i18n.T("config.file_invalid_ignored", key.Name, projectConfigPath, value))
+			}
+		}
+		if value, ok := userConfig.Settings[key.Name]; ok && key.Validate(value) != nil {
+			warnings = append(warnings, i18n.T("config.file_invalid_ignored", key.Name, GetConfigFilePath(), value))
+		}
+	}
+	return warnings
+}
+
+func GetConfig(name string) string {
+	value, _ := GetConfigValue(name)
+	return value
+}
+
+func GetConfigBool(name string) bool {
+	value, _ := strconv.ParseBool(GetConfig(name))
+	return value
+}
+
+func GetConfigInt(name string) int {
+	value, err := strconv.Atoi(GetConfig(name))
+	if err != nil {
+		key, _ := LookupConfigKey(name)
+		value, _ = strconv.Atoi(key.Default)
+	}
+	return value
+}
+
+func GetConfigDuration(name string) time.Duration {
+	value, err := ParseDuration(GetConfig(name))
+	if err != nil {
+		key, _ := LookupConfigKey(name)
+		value, _ = ParseDuration(key.Default)
+	}
+	return value
+}
+
+// GetUserConfigValue devuelve el valor guardado en config.json, si existe
+func GetUserConfigValue(name string) (string, bool) {
+	value, ok := userConfig.Settings[name]
+	return value, ok
+}
+
+// GetUserConfigSettings devuelve una copia de los valores guardados en config.json
+func GetUserConfigSettings() map[string]string {
+	settings := make(map[string]string, len(userConfig.Settings))
+	for name, value := range userConfig.Settings {
+		settings[name] = value
+	}
+	return settings
+}
+
+// SetUserConfigValue valida y guarda un valor en config.json
+func SetUserConfigValue(name, value string) error {
+	key, ok := LookupConfigKey(name)
+	if !ok {
+		return i18n.Errorf("config.unknown_key", name)
+	}
+	if err := key.Validate(value); err != nil {
+		return i18n.Errorf("config.invalid_value", name, err)
+	}
+
+	userConfig.Settings[name] = value
+	return saveUserConfig()
+}
+
+// UnsetUserConfigValue elimina un valor de config.json, volviendo al valor por defecto
+func UnsetUserConfigValue(name string) error {
+	if _, ok := LookupConfigKey(name); !ok {
+		return i18n.Errorf("config.unknown_key", name)
+	}
+
+	delete(userConfig.Settings, name)
+	return saveUserConfig()
+}
+
+/*
+ParseDuration interpreta duraciones en el formato de Go (por ejemplo "90m" o "1h30m"),
+agregando el sufijo "d" para días. Un número sin unidad se interpreta como segundos.
+*/
+func ParseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	if strings.HasSuffix(value, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err != nil {
+			return 0, i18n.Errorf("config.invalid_duration", value)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, i18n.Errorf("config.invalid_duration", value)
+	}
+	return duration, nil
+}
+
+func validateAny(value string) error {
+	return nil
+}
+
+/*
+ParseSize interpreta tamaños como "500MB" o "5GB" (unidades de 1024 bytes).
+Un número sin unidad se interpreta como bytes.
+*/
+func ParseSize(value string) (int64, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	units := []struct {
+		suffix string
+		factor int64
+	}{{"TB", 1 << 40}, {"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}}
+
+	factor := int64(1)
+	for _, unit := range units {
+		if strings.HasSuffix(value, unit.suffix) {
+			value = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix))
+			factor = unit.factor
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n < 0 {
+		return 0, i18n.Errorf("config.invalid_size", value)
+	}
+	return int64(n * float64(factor)), nil
+}
+
+// FormatSize muestra un tamaño en bytes con la unidad más adecuada
+func FormatSize(size int64) string {
+	switch {
+	case size >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(size)/(1<<30))
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(size)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", size)
+}
+
+func validateSize(value string) error {
+	_, err := ParseSize(value)
+	return err
+}
+
+func validateDuration(value string) error {
+	_, err := ParseDuration(value)
+	return err
+}
+
+func validatePositiveInt(value string) error {
+	if n, err := strconv.Atoi(value); err != nil || n < 1 {
+		return i18n.Errorf("config.expected_positive_int")
+	}
+	return nil
+}
+
+func validateBool(value string) error {
+	if _, err := strconv.ParseBool(value); err != nil {
+		return i18n.Errorf("config.expected_bool")
+	}
+	return nil
+}
+
+func validateURL(value string) error {
+	if !strings.HasPrefix(value, "http://") && !strings.HasPrefix(value, "https://") {
+		return i18n.Errorf("config.expected_url")
+	}
+	return nil
+}
+
+func validateOptionalURL(value string) error {
+	if value == "" {
+		return nil
+	}
+	return validateURL(value)
+}
+
+func validateOneOf(options ...string) func(string) error {
+	return func(value string) error {
+		for _, option := range options {
+			if value == option {
+				return nil
+			}
+		}
+		return i18n.Errorf("config.expected_one_of", strings.Join(options, ", "))
+	}
+}
@@ -0,0 +1,61 @@
+package shared
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"polynode/i18n"
+)
+
+const credentialsFileName = "credentials.json"
+
+/*
+Credentials guarda las credenciales del proxy fuera de la URL y de config.json,
+en un archivo con permisos restringidos al usuario.
+*/
+type Credentials struct {
+	ProxyUser     string `json:"proxy_user,omitempty"`
+	ProxyPassword string `json:"proxy_password,omitempty"`
+}
+
+func GetCredentialsFilePath() string {
+	return filepath.Join(installPath, credentialsFileName)
+}
+
+func LoadCredentials() (Credentials, error) {
+	credentials := Credentials{}
+
+	data, err := os.ReadFile(GetCredentialsFilePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return credentials, nil
+		}
+		return credentials, i18n.Errorf("credentials.read_error", err)
+	}
+
+	if err := json.Unmarshal(data, &credentials); err != nil {
+		return credentials, i18n.Errorf("credentials.decode_error", err)
+	}
+
+	return credentials, nil
+}
+
+func SaveCredentials(credentials Credentials) error {
+	if credentials == (Credentials{}) {
+		if err := os.Remove(GetCredentialsFilePath()); err != nil && !os.IsNotExist(err) {
+			return i18n.Errorf("credentials.remove_error", err)
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(credentials, "", "    ")
+	if err != nil {
+		return i18n.Errorf("credentials.serialize_error", err)
+	}
+
+	if err := os.WriteFile(GetCredentialsFilePath(), data, 0600); err != nil {
+		return i18n.Errorf("credentials.write_error", err)
+	}
+
+	return nil
+}
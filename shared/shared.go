@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"polynode/i18n"
 	"strings"
 )
 
@@ -13,14 +14,18 @@ const (
 	defaultInstallPath          = "C:\\polynode"
 	currentVersionPathName      = "current"
 	repoPathName                = "repository"
+	cachePathName               = "cache"
 	nodeRemoteRepositoryBaseURL = "https://nodejs.org/dist/"
-	nodeURLTemplate             = "%s/v%s/node-v%s-%s-x64.zip"
+	nodeScheduleURL             = "https://raw.githubusercontent.com/nodejs/Release/main/schedule.json"
+	nodeURLTemplate             = "%sv%s/%s"
+	versionDirTemplate          = "node-v%s-%s"
 )
 
 var (
 	installPath        string
 	currentVersionPath string
 	repoPath           string
+	cachePath          string
 )
 
 type Version struct {
@@ -39,13 +44,19 @@ func init() {
 	   Initialize InstallPath with the environment variable "POLYNODE_PATH".
 	   If it doesn't exist, use the DefaultInstallPath constant instead.
 	*/
-	installPath = os.Getenv(envAppPath)
-	if installPath == "" {
-		installPath = defaultInstallPath
+	path := os.Getenv(envAppPath)
+	if path == "" {
+		path = defaultInstallPath
 	}
+	SetInstallPath(path)
+}
 
+// SetInstallPath cambia el espacio de trabajo (por ejemplo, con el flag --workspace)
+func SetInstallPath(path string) {
+	installPath = path
 	currentVersionPath = filepath.Join(installPath, currentVersionPathName)
 	repoPath = filepath.Join(installPath, repoPathName)
+	cachePath = filepath.Join(installPath, cachePathName)
 }
 
 func GetInstallPath() string {
@@ -60,6 +71,15 @@ func GetRepoPath() string {
 	return repoPath
 }
 
+func GetCachePath() string {
+	return cachePath
+}
+
+// GetArchiveCachePath devuelve el directorio de la caché de archivos descargados
+func GetArchiveCachePath() string {
+	return filepath.Join(cachePath, "archives")
+}
+
 func getOS() string {
 	if strings.Contains(strings.ToLower(os.Getenv("OS")), "windows") {
 		return "win"
@@ -67,41 +87,120 @@ func getOS() string {
 	return "linux"
 }
 
+// IsWindows indica si las versiones del espacio de trabajo son de Windows (node.exe y scripts .cmd)
+func IsWindows() bool {
+	return getOS() == "win"
+}
+
+// GetPlatform devuelve el sufijo de plataforma de las distribuciones de Node (por ejemplo "win-x64")
+func GetPlatform() string {
+	return fmt.Sprintf("%s-%s", getOS(), GetConfig("arch"))
+}
+
+// GetNodeRepositoryBaseURL devuelve la URL del mirror configurado, siempre terminada en "/"
 func GetNodeRepositoryBaseURL() string {
-	return nodeRemoteRepositoryBaseURL
+	return strings.TrimSuffix(GetConfig("mirror"), "/") + "/"
 }
 
 func GetNodeVersionURL(version string) string {
-	return fmt.Sprintf(nodeURLTemplate, nodeRemoteRepositoryBaseURL, version, version, getOS())
+	return GetNodeArchiveURL(version, GetArchiveName(version))
+}
+
+// GetNodeArchiveURL devuelve la URL de un archivo publicado para una versión (por ejemplo SHASUMS256.txt)
+func GetNodeArchiveURL(version, fileName string) string {
+	return fmt.Sprintf(nodeURLTemplate, GetNodeRepositoryBaseURL(), version, fileName)
+}
+
+// GetVersionDirName devuelve el nombre del directorio de una versión dentro del repositorio
+func GetVersionDirName(version string) string {
+	return fmt.Sprintf(versionDirTemplate, version, GetPlatform())
+}
+
+// GetArchiveName devuelve el nombre del archivo de distribución de una versión para la plataforma actual
+func GetArchiveName(version string) string {
+	return GetArchiveNameFor(version, GetPlatform())
+}
+
+// GetArchiveNameFor devuelve el nombre del archivo de distribución de una versión para una plataforma
+func GetArchiveNameFor(version, platform string) string {
+	extension := ".tar.gz"
+	if strings.HasPrefix(platform, "win-") {
+		extension = ".zip"
+	}
+	return fmt.Sprintf(versionDirTemplate, version, platform) + extension
 }
 
+func GetVersionPath(version string) string {
+	return filepath.Join(repoPath, GetVersionDirName(version))
+}
+
+// GetNodeExecutable devuelve la ruta del ejecutable de Node dentro de un directorio de versión
+func GetNodeExecutable(versionPath string) string {
+	if getOS() == "win" {
+		return filepath.Join(versionPath, "node.exe")
+	}
+	return filepath.Join(versionPath, "bin", "node")
+}
+
+// GetGlobalModulesPath devuelve el directorio de los paquetes globales de npm de un directorio de versión
+func GetGlobalModulesPath(versionPath string) string {
+	if getOS() == "win" {
+		return filepath.Join(versionPath, "node_modules")
+	}
+	return filepath.Join(versionPath, "lib", "node_modules")
+}
+
+// GetBinPath devuelve el directorio de los ejecutables (node, npm y los shims de Corepack) de un directorio de versión
+func GetBinPath(versionPath string) string {
+	return filepath.Dir(GetNodeExecutable(versionPath))
+}
+
+// GetCorepackCli devuelve la ruta del script de Corepack incluido en un directorio de versión
+func GetCorepackCli(versionPath string) string {
+	return filepath.Join(GetGlobalModulesPath(versionPath), "corepack", "dist", "corepack.js")
+}
+
+// GetNpmCli devuelve la ruta del script de npm incluido en un directorio de versión
+func GetNpmCli(versionPath string) string {
+	return filepath.Join(GetGlobalModulesPath(versionPath), "npm", "bin", "npm-cli.js")
+}
+
+// GetCurrentVersion devuelve la versión seleccionada, mostrando el error si no se pudo obtener
 func GetCurrentVersion() string {
+	version, err := CurrentVersion()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	return version
+}
+
+// CurrentVersion devuelve la versión seleccionada, o una cadena vacía si no hay ninguna
+func CurrentVersion() (string, error) {
 	// Verificar si el directorio "current" existe
 	_, err := os.Stat(currentVersionPath)
 	if err != nil && os.IsNotExist(err) {
-		return ""
+		return "", nil
 	}
 
 	// Obtener la ruta completa del ejecutable de Node.js
-	nodeExec := filepath.Join(currentVersionPath, "node.exe")
+	nodeExec := GetNodeExecutable(currentVersionPath)
 
 	// Verificar si el ejecutable de Node.js existe
 	_, err = os.Stat(nodeExec)
 	if err != nil && os.IsNotExist(err) {
-		return ""
+		return "", nil
 	}
 
 	// Ejecutar "node -v" para obtener la versión de Node.js
 	cmd := exec.Command(nodeExec, "-v")
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		fmt.Println("Error al obtener la versión de Node.js:", err)
-		return ""
+		return "", i18n.Errorf("node.version_error", err)
 	}
 
 	// Extraer y formatear la versión de Node.js
 	version := strings.TrimSpace(string(output))
-	return NormalizeVersion(version)
+	return NormalizeVersion(version), nil
 }
 
 func NormalizeVersion(version string) string {